@@ -0,0 +1,160 @@
+package chart
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+)
+
+// TicksPerSec adalah satuan waktu chart: berapa "tick" dalam satu detik.
+const TicksPerSec = 100.0
+
+// defaultBpm dipakai untuk chart lama yang tidak punya timing point. Ini bukan
+// tempo lagu; yang butuh tempo sebenarnya cek HasTempo dulu.
+const defaultBpm = 120.0
+
+type LaneId uint
+
+const (
+	GuitarLaneId LaneId = iota
+	DrumsLaneId
+	BassLaneId
+)
+
+type Note struct {
+	Lane      LaneId  // Lajur tempat not ini berada (0 hingga laneCount-1).
+	Tick      float64 // Waktu (dalam "tick") kapan not ini harusnya ditekan.
+	IsActive  bool    // Status apakah not ini masih dalam permainan (belum ditekan atau terlewat).
+	YPosition float64 // Posisi Y not di layar saat ini.
+}
+
+// TimingPoint menandai tempo lagu mulai dari Tick tertentu.
+type TimingPoint struct {
+	Tick float64
+	Bpm  float64
+}
+
+type Chart struct {
+	AudioStart   float64 // Tick saat stem mulai diputar dari posisi 0.
+	LeadIn       float64 // Jeda (dalam tick) sebelum AudioStart, untuk bersiap.
+	TimingPoints []TimingPoint
+	Scoring      string // Aturan skor wajib untuk chart ini; kosong = pilihan pemain.
+	Difficulty   string
+	Notes        []*Note
+
+	Hash string `json:"-"` // SHA-256 isi chart, kunci untuk skor tersimpan.
+}
+
+// DefaultDifficulty dipakai chart yang tidak menyebut tingkat kesulitan.
+const DefaultDifficulty = "Normal"
+
+// Parse membaca chart dalam format objek, atau format lama berupa array not
+// saja (dengan AudioStart bawaan 650 tick).
+func Parse(data []byte) (*Chart, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("chart: empty data")
+	}
+
+	sum := sha256.Sum256(data)
+	c := &Chart{Hash: hex.EncodeToString(sum[:])}
+	if data[0] == '[' {
+		c.AudioStart = 650
+		c.Difficulty = DefaultDifficulty
+		if err := json.Unmarshal(data, &c.Notes); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	if c.Difficulty == "" {
+		c.Difficulty = DefaultDifficulty
+	}
+	return c, nil
+}
+
+// StartTick adalah posisi awal lagu termasuk lead-in.
+func (c *Chart) StartTick() float64 {
+	return c.AudioStart - c.LeadIn
+}
+
+// FirstTick mengembalikan tick not pertama, atau AudioStart jika chart kosong.
+func (c *Chart) FirstTick() float64 {
+	if len(c.Notes) == 0 {
+		return c.AudioStart
+	}
+	first := math.Inf(1)
+	for _, note := range c.Notes {
+		first = math.Min(first, note.Tick)
+	}
+	return first
+}
+
+// LastTick mengembalikan tick not terakhir.
+func (c *Chart) LastTick() float64 {
+	last := 0.0
+	for _, note := range c.Notes {
+		last = math.Max(last, note.Tick)
+	}
+	return last
+}
+
+// HasTempo benar jika chart menyebut tempo lagunya lewat timing point.
+func (c *Chart) HasTempo() bool {
+	return len(c.TimingPoints) > 0
+}
+
+// TimingPointAt mengembalikan timing point yang berlaku pada tick.
+func (c *Chart) TimingPointAt(tick float64) TimingPoint {
+	if len(c.TimingPoints) == 0 {
+		return TimingPoint{Tick: c.FirstTick(), Bpm: defaultBpm}
+	}
+	tp := c.TimingPoints[0]
+	for _, p := range c.TimingPoints[1:] {
+		if p.Tick > tick {
+			break
+		}
+		tp = p
+	}
+	return tp
+}
+
+// BeatTicks adalah panjang satu ketukan (dalam tick) pada tick.
+func (c *Chart) BeatTicks(tick float64) float64 {
+	return TicksPerSec * 60 / c.TimingPointAt(tick).Bpm
+}
+
+// Beats mengembalikan tick setiap ketukan dalam rentang [from, to) mengikuti
+// timing point chart. Timing point pertama juga berlaku sebelum Tick-nya.
+func (c *Chart) Beats(from, to float64) []float64 {
+	points := c.TimingPoints
+	if len(points) == 0 {
+		points = []TimingPoint{c.TimingPointAt(from)}
+	}
+
+	var beats []float64
+	for i, tp := range points {
+		start, end := from, to
+		if i > 0 {
+			start = math.Max(from, tp.Tick)
+		}
+		if i+1 < len(points) {
+			end = math.Min(to, points[i+1].Tick)
+		}
+		beat := TicksPerSec * 60 / tp.Bpm
+		for k := math.Ceil((start - tp.Tick) / beat); ; k++ {
+			t := tp.Tick + k*beat
+			if t >= end {
+				break
+			}
+			beats = append(beats, t)
+		}
+	}
+	return beats
+}
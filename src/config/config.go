@@ -0,0 +1,110 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/judge"
+	"github.com/rizalmf/old-boys/src/scoring"
+)
+
+const fileName = "config.json"
+
+// Config menyimpan pengaturan pemain di antara sesi permainan.
+type Config struct {
+	SkipIntro       bool             // Lompati intro panjang sampai beberapa ketukan sebelum not pertama.
+	MetronomeVolume float64          // Volume klik metronome (0 = mati, 1 = penuh).
+	SongRate        float64          // Kecepatan lagu, 0.5x sampai 1.5x.
+	Judgement       judge.Preset     // Preset window penilaian.
+	ComboMultiplier bool             // Kalikan poin sesuai tingkat combo (x2/x3/x4).
+	Grades          judge.Thresholds // Batas akurasi untuk grade S/A/B/C.
+	NoFail          bool             // Lagu tetap berjalan walau health habis.
+	GhostTapPenalty bool             // Tekanan di luar semua window dihitung sebagai bad tap.
+	Scoring         string           // Aturan skor, lihat scoring.Names.
+	Ghost           bool             // Main melawan replay skor terbaik.
+	AutoplayJitter  float64          // Simpangan timing autoplay (ms); 0 = selalu tepat.
+	Keys            [][]string       // Nama tombol keyboard setiap lajur, lihat ebiten.Key.
+	Buttons         [][]string       // Nama tombol gamepad (gaya Xbox) setiap lajur.
+	TouchLayout     string           // Tata letak area sentuh lajur; kosong = di bawah ikon.
+	TouchZones      bool             // Tampilkan area sentuh di atas layar (debug).
+}
+
+func Default() Config {
+	return Config{
+		SongRate: 1,
+		Grades:   judge.DefaultThresholds(),
+		Scoring:  scoring.Classic,
+		Keys:     DefaultKeys(),
+		Buttons:  DefaultButtons(),
+	}
+}
+
+// DefaultKeys adalah tombol bawaan lajur gitar, drum dan bass: D/F/J dan
+// panah.
+func DefaultKeys() [][]string {
+	return [][]string{
+		{"D", "ArrowLeft"},
+		{"F", "ArrowDown"},
+		{"J", "ArrowRight"},
+	}
+}
+
+// DefaultButtons adalah tombol gamepad bawaan: D-pad seperti panah di
+// keyboard, dan trigger. Tombol muka dipakai menu (A pilih, B kembali,
+// X kosongkan) jadi tidak dipakai lajur.
+func DefaultButtons() [][]string {
+	return [][]string{
+		{"Left", "LT"},
+		{"Down", "LB"},
+		{"Right", "RT"},
+	}
+}
+
+// Dir mengembalikan folder data user untuk game ini.
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, constants.AppDir), nil
+}
+
+// Load membaca config dari folder data user. Jika file belum ada atau tidak
+// bisa dibaca, pengaturan bawaan yang dipakai.
+func Load() (Config, error) {
+	c := Default()
+	dir, err := Dir()
+	if err != nil {
+		return c, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, fileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Default(), err
+	}
+	return c, nil
+}
+
+// Save menulis config ke folder data user.
+func (c Config) Save() error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, fileName), data, 0o644)
+}
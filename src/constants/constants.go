@@ -2,6 +2,7 @@ package constants
 
 const (
 	GameTitle = "Old Boys"
+	AppDir    = "old-boys" // Nama folder data user.
 
 	TPS = 60
 
@@ -9,4 +10,8 @@ const (
 	ScreenHeight = 405
 
 	TileSize = 32
+
+	// Rentang kecepatan lagu.
+	MinSongRate = 0.5
+	MaxSongRate = 1.5
 )
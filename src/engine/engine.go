@@ -0,0 +1,252 @@
+// Package engine menilai satu run pada sebuah chart: not per lajur,
+// penilaian, combo, health dan skor. Engine tidak butuh window maupun audio,
+// jadi permainan, ghost, dan simulasi headless memakai kode yang sama.
+package engine
+
+import (
+	"github.com/rizalmf/old-boys/src/chart"
+	"github.com/rizalmf/old-boys/src/config"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/judge"
+	"github.com/rizalmf/old-boys/src/scoring"
+)
+
+// LaneCount adalah jumlah lajur: gitar, drum dan bass.
+const LaneCount = 3
+
+const (
+	HealthStart    = 0.5
+	ghostTapHealth = -0.04
+)
+
+// Counts adalah jumlah setiap tingkat penilaian.
+type Counts [judge.JudgementCount]int
+
+func (c Counts) Total() int {
+	n := 0
+	for _, v := range c {
+		n += v
+	}
+	return n
+}
+
+// NoteResult adalah penilaian satu not. Index menunjuk ke Chart.Notes.
+type NoteResult struct {
+	Index     int
+	Lane      chart.LaneId
+	Tick      float64
+	Judgement judge.Judgement
+	OffsetMs  float64 // Selisih waktu tekan; negatif = terlalu cepat, 0 untuk miss.
+}
+
+// Hit adalah hasil sebuah tekanan atau not yang terlewat, untuk ditampilkan.
+type Hit struct {
+	Note      *chart.Note // nil untuk ghost tap.
+	Lane      chart.LaneId
+	Judgement judge.Judgement
+	OffsetMs  float64
+	Failed    bool // Run gagal karena hit ini.
+}
+
+// Run adalah state penilaian satu run. Setiap Run punya salinan not sendiri,
+// jadi beberapa run bisa dinilai pada chart yang sama tanpa saling ganggu.
+type Run struct {
+	Rule            scoring.Rule
+	Preset          judge.Preset
+	Rate            float64 // Kecepatan lagu.
+	NoFail          bool
+	GhostTapPenalty bool
+
+	Lanes     [LaneCount]Counts
+	Points    Counts    // Poin dari aturan skor untuk setiap tingkat penilaian.
+	Offsets   []float64 // Selisih waktu (ms) setiap not yang kena.
+	Combo     int       // Not berturut-turut yang kena sejak miss terakhir.
+	MaxCombo  int
+	Health    float64 // 0 sampai 1; gagal saat habis.
+	Failed    bool
+	GhostTaps int // Tekanan yang tidak mengenai not mana pun.
+	Notes     []NoteResult
+
+	firstTick float64
+	queues    [LaneCount]laneQueue
+	index     map[*chart.Note]int
+}
+
+// New menyiapkan run untuk chart dengan pengaturan cfg. Aturan skor dari
+// chart didahulukan atas pilihan di cfg, dan kecepatan lagu dibatasi seperti
+// saat dimainkan.
+func New(c *chart.Chart, cfg config.Config) *Run {
+	r := &Run{
+		Rule: scoring.New(RuleName(c, cfg), scoring.Options{
+			TotalNotes: len(c.Notes),
+			Multiplier: cfg.ComboMultiplier,
+			Grades:     cfg.Grades,
+		}),
+		Preset:          cfg.Judgement,
+		Rate:            SongRate(cfg.SongRate),
+		NoFail:          cfg.NoFail,
+		GhostTapPenalty: cfg.GhostTapPenalty,
+		Health:          HealthStart,
+		firstTick:       c.FirstTick(),
+		index:           make(map[*chart.Note]int, len(c.Notes)),
+	}
+
+	notes := make([]*chart.Note, len(c.Notes))
+	for i, note := range c.Notes {
+		n := *note
+		notes[i] = &n
+		r.index[&n] = i
+	}
+	r.queues = newLaneQueues(notes)
+	return r
+}
+
+// SongRate membatasi kecepatan lagu ke rentang yang didukung audio. Nol atau
+// negatif berarti kecepatan normal.
+func SongRate(rate float64) float64 {
+	if rate <= 0 {
+		return 1
+	}
+	return min(max(rate, constants.MinSongRate), constants.MaxSongRate)
+}
+
+// RuleName adalah aturan skor run pada chart c: aturan dari chart
+// didahulukan atas pilihan di cfg.
+func RuleName(c *chart.Chart, cfg config.Config) string {
+	if c.Scoring != "" {
+		return c.Scoring
+	}
+	return cfg.Scoring
+}
+
+// MsToTicks mengubah milidetik (waktu nyata) ke tick chart sesuai kecepatan lagu.
+func (r *Run) MsToTicks(ms float64) float64 {
+	return ms / 1000 * chart.TicksPerSec * r.Rate
+}
+
+func (r *Run) TicksToMs(ticks float64) float64 {
+	return ticks / (chart.TicksPerSec * r.Rate) * 1000
+}
+
+// Visible mengembalikan not lajur yang belum dinilai dan sudah masuk layar.
+func (r *Run) Visible(lane int) []*chart.Note {
+	return r.queues[lane].visible()
+}
+
+// Advance menilai miss setiap not yang sudah lewat window terakhir pada tick,
+// dan memperluas not yang terlihat sampai lookAhead tick ke depan. fn
+// (boleh nil) dipanggil untuk setiap miss. Setelah gagal tidak ada lagi not
+// yang dinilai.
+func (r *Run) Advance(tick, lookAhead float64, fn func(Hit)) {
+	missAfter := r.MsToTicks(r.Preset.Windows().Bad)
+	for i := range r.queues {
+		r.queues[i].advance(tick, missAfter, lookAhead, func(note *chart.Note) {
+			if r.Failed {
+				return
+			}
+			hit := r.judge(note, judge.Miss, 0)
+			if fn != nil {
+				fn(hit)
+			}
+		})
+	}
+}
+
+// Press menilai tekanan lajur pada tick terhadap not aktif terdekat. Tekanan
+// di luar semua window menjadi ghost tap jika hukumannya aktif. ok bernilai
+// false jika tekanan itu tidak berpengaruh.
+func (r *Run) Press(lane int, tick float64) (hit Hit, ok bool) {
+	if r.Failed || lane < 0 || lane >= LaneCount {
+		return Hit{}, false
+	}
+	windows := r.Preset.Windows()
+	if note := r.queues[lane].nearest(tick, r.MsToTicks(windows.Bad)); note != nil {
+		offset := r.TicksToMs(tick - note.Tick)
+		if j, ok := windows.Judge(offset); ok {
+			return r.judge(note, j, offset), true
+		}
+	}
+	return r.ghostTap(chart.LaneId(lane), tick)
+}
+
+// judge mencatat penilaian sebuah not.
+func (r *Run) judge(note *chart.Note, j judge.Judgement, offset float64) Hit {
+	note.IsActive = false
+
+	r.Lanes[note.Lane][j]++
+	if j == judge.Miss {
+		r.Combo = 0
+	} else {
+		r.Combo++
+		r.MaxCombo = max(r.MaxCombo, r.Combo)
+		r.Offsets = append(r.Offsets, offset)
+	}
+	failed := r.changeHealth(healthGain(j))
+	before := r.Rule.Score()
+	r.Rule.Judge(scoring.Event{Judgement: j, OffsetMs: offset, Combo: r.Combo})
+	r.Points[j] += r.Rule.Score() - before
+	r.Notes = append(r.Notes, NoteResult{
+		Index:     r.index[note],
+		Lane:      note.Lane,
+		Tick:      note.Tick,
+		Judgement: j,
+		OffsetMs:  offset,
+	})
+	return Hit{Note: note, Lane: note.Lane, Judgement: j, OffsetMs: offset, Failed: failed}
+}
+
+// ghostTap menghukum tekanan yang tidak mengenai not mana pun jika opsi ghost
+// tap aktif dan not pertama sudah dekat: combo putus dan health berkurang.
+func (r *Run) ghostTap(lane chart.LaneId, tick float64) (Hit, bool) {
+	if !r.GhostTapPenalty || tick < r.firstTick-r.MsToTicks(r.Preset.Windows().Bad) {
+		return Hit{}, false
+	}
+	r.GhostTaps++
+	r.Combo = 0
+	return Hit{Lane: lane, Judgement: judge.Miss, Failed: r.changeHealth(ghostTapHealth)}, true
+}
+
+// changeHealth mengubah health. Hasilnya true jika health baru saja habis dan
+// modifier no-fail tidak aktif.
+func (r *Run) changeHealth(delta float64) bool {
+	if r.Failed {
+		return false
+	}
+	r.Health = min(max(r.Health+delta, 0), 1)
+	if r.Health == 0 && !r.NoFail {
+		r.Failed = true
+		return true
+	}
+	return false
+}
+
+// healthGain adalah perubahan health untuk setiap tingkat penilaian.
+func healthGain(j judge.Judgement) float64 {
+	switch j {
+	case judge.Perfect:
+		return 0.02
+	case judge.Great:
+		return 0.015
+	case judge.Good:
+		return 0.01
+	case judge.Bad:
+		return -0.02
+	}
+	return -0.08
+}
+
+// Counts menjumlahkan penilaian semua lajur.
+func (r *Run) Counts() Counts {
+	var total Counts
+	for _, c := range r.Lanes {
+		for j, n := range c {
+			total[j] += n
+		}
+	}
+	return total
+}
+
+// FullCombo benar jika tidak ada satu pun not yang miss atau ghost tap.
+func (r *Run) FullCombo() bool {
+	return r.MaxCombo > 0 && r.GhostTaps == 0 && r.Counts()[judge.Miss] == 0
+}
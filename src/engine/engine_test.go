@@ -0,0 +1,313 @@
+package engine
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/rizalmf/old-boys/assets/notes"
+	"github.com/rizalmf/old-boys/src/chart"
+	"github.com/rizalmf/old-boys/src/config"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/input"
+	"github.com/rizalmf/old-boys/src/judge"
+	"github.com/rizalmf/old-boys/src/replay"
+)
+
+func testChart(t *testing.T) *chart.Chart {
+	c, err := chart.Parse(notes.Note_json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// sortedNotes mengembalikan not chart terurut berdasarkan tick.
+func sortedNotes(c *chart.Chart) []*chart.Note {
+	sorted := slices.Clone(c.Notes)
+	slices.SortStableFunc(sorted, func(a, b *chart.Note) int { return cmp.Compare(a.Tick, b.Tick) })
+	return sorted
+}
+
+// live memainkan replay per frame seperti scene: tick maju dan dibulatkan
+// setiap frame, tekanan datang dari skrip input lewat Manager dan not yang
+// lewat dinilai miss sampai waktu setiap event, rewind memindahkan tick dan
+// memasang skrip bagian berikutnya, lalu sisa miss frame itu dinilai.
+func live(c *chart.Chart, cfg config.Config, r *replay.Replay) Result {
+	run := New(c, cfg)
+	step := chart.TicksPerSec / constants.TPS * run.Rate
+	end := c.LastTick() + chart.TicksPerSec
+	script, seek := r.Script(0)
+	m := input.NewManager(script)
+	tick := c.StartTick()
+	for tick < end {
+		tick = replay.Quantize(tick + step)
+		for {
+			at := tick
+			rewind := seek < len(r.Events) && r.Events[seek].Tick <= tick
+			if rewind {
+				at = r.Events[seek].Tick
+			}
+			m.Update(at)
+			for _, e := range m.Events() {
+				if lane, ok := e.Action.LaneIndex(); ok && e.Pressed {
+					run.Advance(e.At, 0, nil)
+					run.Press(lane, e.At)
+				}
+			}
+			if !rewind {
+				break
+			}
+			run.Advance(at, 0, nil)
+			tick = r.Events[seek].To
+			m.Remove(script)
+			script, seek = r.Script(seek + 1)
+			m.Add(script)
+		}
+		run.Advance(tick, 0, nil)
+	}
+	run.Advance(math.Inf(1), 0, nil)
+	return run.Result()
+}
+
+func checkSimulate(t *testing.T, c *chart.Chart, cfg config.Config, r *replay.Replay) Result {
+	t.Helper()
+	want := live(c, cfg, r)
+	got := Simulate(c, cfg, ReplayInputs(r))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Simulate: counts %v, score %d, ghost taps %d; live: counts %v, score %d, ghost taps %d",
+			got.Counts, got.Score, got.GhostTaps, want.Counts, want.Score, want.GhostTaps)
+	}
+	points := 0
+	for _, p := range got.Points {
+		points += p
+	}
+	if points != got.Score {
+		t.Errorf("points per judgement add up to %d, score is %d", points, got.Score)
+	}
+	return got
+}
+
+// Jitter 10 ms masih di dalam window perfect Standard (40 ms).
+func TestSimulateClean(t *testing.T) {
+	c := testChart(t)
+	cfg := config.Default()
+	got := checkSimulate(t, c, cfg, replay.Autoplay(c, cfg, 10, rand.New(rand.NewPCG(1, 2))))
+	n := len(c.Notes)
+	if want := (Counts{judge.Perfect: n}); got.Counts != want || got.MaxCombo != n || !got.FullCombo {
+		t.Errorf("autoplay: counts %v, max combo %d, full combo %v; want %v, %d, true",
+			got.Counts, got.MaxCombo, got.FullCombo, want, n)
+	}
+}
+
+// handChart membuat chart dengan satu not setiap detik (100 tick) mulai tick
+// 1000, bergantian di ketiga lajur.
+func handChart(n int) *chart.Chart {
+	c := &chart.Chart{}
+	for i := range n {
+		c.Notes = append(c.Notes, &chart.Note{Lane: chart.LaneId(i % LaneCount), Tick: 1000 + 100*float64(i), IsActive: true})
+	}
+	return c
+}
+
+// Batas window ditulis dalam tick (10 ms per tick pada 1x): tepat di batas
+// masih masuk, 0.1 tick sesudahnya sudah tingkat berikutnya.
+func TestJudgementWindowEdges(t *testing.T) {
+	tests := []struct {
+		preset judge.Preset
+		rate   float64
+		edges  [4]float64 // Batas perfect, great, good dan bad dalam tick.
+	}{
+		{judge.PresetStandard, 1, [4]float64{4, 8, 12, 16}},
+		{judge.PresetStrict, 1, [4]float64{2.5, 5, 8.5, 12}},
+		{judge.PresetLenient, 1, [4]float64{6, 11, 16, 20}},
+		{judge.PresetStandard, 1.5, [4]float64{6, 12, 18, 24}},
+		{judge.PresetStrict, 0.5, [4]float64{1.25, 2.5, 4.25, 6}},
+	}
+	for _, tt := range tests {
+		cfg := config.Default()
+		cfg.Judgement = tt.preset
+		cfg.SongRate = tt.rate
+		for j, edge := range tt.edges {
+			for _, sign := range []float64{-1, 1} {
+				for _, c := range []struct {
+					offset float64
+					want   judge.Judgement
+				}{
+					{edge, judge.Judgement(j)},
+					{edge + 0.1, judge.Judgement(j + 1)},
+				} {
+					got := Simulate(handChart(1), cfg, []Input{{Lane: 0, Tick: 1000 + sign*c.offset}})
+					if len(got.Notes) != 1 || got.Notes[0].Judgement != c.want {
+						t.Errorf("%v at %vx, press %+v ticks: %v, want %v", tt.preset, tt.rate, sign*c.offset, got.Notes, c.want)
+						continue
+					}
+					wantMs := sign * c.offset * 10 / tt.rate
+					if c.want == judge.Miss {
+						wantMs = 0
+					}
+					if ms := got.Notes[0].OffsetMs; math.Abs(ms-wantMs) > 1e-9 {
+						t.Errorf("%v at %vx, press %+v ticks: offset %v ms, want %v", tt.preset, tt.rate, sign*c.offset, ms, wantMs)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Dua belas not ditekan dengan selisih tetap (ms; NaN = tidak ditekan), lalu
+// skor classic tanpa pengali dihitung tangan untuk setiap preset.
+func TestPresetResults(t *testing.T) {
+	offsets := []float64{0, -30, 45, -70, 100, -130, 150, -190, math.NaN(), 0, 0, 0}
+	c := handChart(len(offsets))
+	var inputs []Input
+	for i, ms := range offsets {
+		if !math.IsNaN(ms) {
+			inputs = append(inputs, Input{Lane: i % LaneCount, Tick: 1000 + 100*float64(i) + ms/10})
+		}
+	}
+
+	tests := []struct {
+		preset   judge.Preset
+		counts   Counts
+		score    int
+		maxCombo int
+	}{
+		// Standard 40/80/120/160: P P Gr Gr Go B B M M P P P.
+		{judge.PresetStandard, Counts{5, 2, 1, 2, 2}, 5*100 + 2*75 + 50 + 2*20, 7},
+		// Strict 25/50/85/120: P Gr Gr Go B M M M M P P P.
+		{judge.PresetStrict, Counts{4, 2, 1, 1, 4}, 4*100 + 2*75 + 50 + 20, 5},
+		// Lenient 60/110/160/200: P P P Gr Gr Go Go B M P P P.
+		{judge.PresetLenient, Counts{6, 2, 2, 1, 1}, 6*100 + 2*75 + 2*50 + 20, 8},
+	}
+	for _, tt := range tests {
+		cfg := config.Default()
+		cfg.Judgement = tt.preset
+		got := Simulate(c, cfg, inputs)
+		if got.Counts != tt.counts || got.Score != tt.score || got.MaxCombo != tt.maxCombo {
+			t.Errorf("%v: counts %v, score %d, max combo %d; want %v, %d, %d",
+				tt.preset, got.Counts, got.Score, got.MaxCombo, tt.counts, tt.score, tt.maxCombo)
+		}
+		if got.FullCombo || got.Failed || got.GhostTaps != 0 {
+			t.Errorf("%v: full combo %v, failed %v, ghost taps %d", tt.preset, got.FullCombo, got.Failed, got.GhostTaps)
+		}
+		if got.Counts.Total() != len(offsets) {
+			t.Errorf("%v: %d notes judged, want %d", tt.preset, got.Counts.Total(), len(offsets))
+		}
+	}
+}
+
+func TestSimulatePausedAndRewound(t *testing.T) {
+	c := testChart(t)
+	cfg := config.Default()
+	sorted := sortedNotes(c)
+	k := len(sorted) / 2
+	target := sorted[k]
+
+	// Pause tepat setelah window not k lewat, lalu rewind ke sebelum not itu.
+	// Not itu sudah miss saat rewind, jadi tekanan setelahnya tidak mengenainya.
+	from := replay.Quantize(target.Tick + New(c, cfg).MsToTicks(cfg.Judgement.Windows().Bad) + 0.01)
+	r := replay.New(c.Hash, c.Difficulty, cfg)
+	for _, note := range sorted[:k] {
+		r.Press(int(note.Lane), replay.Quantize(note.Tick))
+	}
+	r.Seek(from, replay.Quantize(target.Tick-2*chart.TicksPerSec))
+	for _, note := range sorted[k:] {
+		r.Press(int(note.Lane), replay.Quantize(note.Tick))
+	}
+
+	got := checkSimulate(t, c, cfg, r)
+	for _, n := range got.Notes {
+		if c.Notes[n.Index] == target && n.Judgement != judge.Miss {
+			t.Errorf("note missed before the rewind judged %v", n.Judgement)
+		}
+	}
+}
+
+func TestSimulateGhostTaps(t *testing.T) {
+	c := testChart(t)
+	cfg := config.Default()
+	cfg.GhostTapPenalty = true
+	rng := rand.New(rand.NewPCG(3, 4))
+	r := replay.Autoplay(c, cfg, 10, rng)
+	for range 40 {
+		tick := c.FirstTick() + rng.Float64()*(c.LastTick()-c.FirstTick())
+		r.Press(rng.IntN(LaneCount), replay.Quantize(tick))
+	}
+	slices.SortStableFunc(r.Events, func(a, b replay.Event) int { return cmp.Compare(a.Tick, b.Tick) })
+
+	if got := checkSimulate(t, c, cfg, r); got.GhostTaps == 0 {
+		t.Error("no ghost taps counted")
+	}
+}
+
+func TestNewClampsRate(t *testing.T) {
+	c := testChart(t)
+	for _, tt := range []struct{ rate, want float64 }{
+		{0, 1},
+		{-1, 1},
+		{0.1, constants.MinSongRate},
+		{3, constants.MaxSongRate},
+		{1.2, 1.2},
+	} {
+		cfg := config.Default()
+		cfg.SongRate = tt.rate
+		if got := New(c, cfg).Rate; got != tt.want {
+			t.Errorf("New with rate %v: Rate = %v, want %v", tt.rate, got, tt.want)
+		}
+	}
+}
+
+// ghostTapLane mencari lajur tanpa not dalam window di sekitar tick.
+func ghostTapLane(t *testing.T, c *chart.Chart, cfg config.Config, tick float64) int {
+	window := New(c, cfg).MsToTicks(cfg.Judgement.Windows().Bad)
+lanes:
+	for lane := range LaneCount {
+		for _, note := range c.Notes {
+			if int(note.Lane) == lane && math.Abs(note.Tick-tick) <= window {
+				continue lanes
+			}
+		}
+		return lane
+	}
+	t.Fatalf("every lane has a note near tick %v", tick)
+	return 0
+}
+
+func TestGhostTapPenalty(t *testing.T) {
+	c := testChart(t)
+	tick := sortedNotes(c)[0].Tick
+	for _, penalty := range []bool{false, true} {
+		cfg := config.Default()
+		cfg.GhostTapPenalty = penalty
+		inputs := ReplayInputs(replay.Autoplay(c, cfg, 0, nil))
+		tap := Input{Lane: ghostTapLane(t, c, cfg, tick), Tick: tick}
+
+		run := New(c, cfg)
+		run.Play(inputs[:1])
+		health := run.Health
+		run.Play([]Input{tap})
+		if drained := run.Health < health; drained != penalty {
+			t.Errorf("penalty %v: health after ghost tap = %v", penalty, run.Health)
+		}
+		if broken := run.Combo == 0; broken != penalty {
+			t.Errorf("penalty %v: combo after ghost tap = %d", penalty, run.Combo)
+		}
+
+		run.Play(inputs[1:])
+		run.Advance(math.Inf(1), 0, nil)
+		got := run.Result()
+		if got.Counts[judge.Miss] != 0 {
+			t.Fatalf("penalty %v: counts = %v", penalty, got.Counts)
+		}
+		if taps := map[bool]int{false: 0, true: 1}[penalty]; got.GhostTaps != taps {
+			t.Errorf("penalty %v: ghost taps = %d, want %d", penalty, got.GhostTaps, taps)
+		}
+		if got.FullCombo == penalty {
+			t.Errorf("penalty %v: full combo = %v", penalty, got.FullCombo)
+		}
+	}
+}
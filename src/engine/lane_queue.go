@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"math"
+	"sort"
+
+	"github.com/rizalmf/old-boys/src/chart"
+)
+
+// laneQueue menyimpan not satu lajur terurut berdasarkan tick. Not di depan
+// head sudah dinilai, dan notes[head:end] adalah not yang sudah masuk layar,
+// jadi setiap frame hanya menyentuh not yang terlihat.
+type laneQueue struct {
+	notes []*chart.Note
+	head  int // Not pertama yang belum dinilai.
+	end   int // Batas (eksklusif) not yang sudah masuk layar.
+}
+
+func newLaneQueues(notes []*chart.Note) [LaneCount]laneQueue {
+	var queues [LaneCount]laneQueue
+	for _, note := range notes {
+		if note.Lane < LaneCount {
+			queues[note.Lane].notes = append(queues[note.Lane].notes, note)
+		}
+	}
+	for i := range queues {
+		q := queues[i].notes
+		sort.SliceStable(q, func(a, b int) bool { return q[a].Tick < q[b].Tick })
+	}
+	return queues
+}
+
+// advance melewati not yang sudah dinilai, memanggil miss untuk not aktif
+// yang sudah lewat lebih dari missAfter tick, dan memperluas not yang
+// terlihat sampai lookAhead tick ke depan.
+func (q *laneQueue) advance(currentTick, missAfter, lookAhead float64, miss func(note *chart.Note)) {
+	for q.head < len(q.notes) {
+		note := q.notes[q.head]
+		if note.IsActive && currentTick-note.Tick <= missAfter {
+			break
+		}
+		if note.IsActive {
+			miss(note)
+		}
+		q.head++
+	}
+
+	q.end = max(q.end, q.head)
+	for q.end < len(q.notes) && q.notes[q.end].Tick-currentTick <= lookAhead {
+		q.end++
+	}
+}
+
+// visible mengembalikan not yang belum dinilai dan sudah masuk layar.
+func (q *laneQueue) visible() []*chart.Note {
+	return q.notes[q.head:q.end]
+}
+
+// nearest mencari not aktif terdekat dari currentTick dalam jarak window tick.
+func (q *laneQueue) nearest(currentTick, window float64) *chart.Note {
+	var best *chart.Note
+	bestDiff := math.Inf(1)
+	for _, note := range q.notes[q.head:] {
+		if note.Tick-currentTick > window {
+			break
+		}
+		if !note.IsActive {
+			continue
+		}
+		if diff := math.Abs(note.Tick - currentTick); diff <= window && diff < bestDiff {
+			best, bestDiff = note, diff
+		}
+	}
+	return best
+}
@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"math"
+
+	"github.com/rizalmf/old-boys/src/chart"
+	"github.com/rizalmf/old-boys/src/config"
+	"github.com/rizalmf/old-boys/src/judge"
+	"github.com/rizalmf/old-boys/src/replay"
+)
+
+// Input adalah satu tekanan lajur pada tick chart, atau rewind dari Tick jika
+// Seek bernilai true.
+type Input struct {
+	Lane int
+	Tick float64
+	Seek bool
+}
+
+// Result adalah hasil lengkap sebuah run.
+type Result struct {
+	Lanes     [LaneCount]Counts
+	Counts    Counts
+	Points    Counts // Poin setiap tingkat penilaian; jumlahnya sama dengan Score.
+	Score     int
+	Accuracy  float64
+	Grade     judge.Grade
+	MaxCombo  int
+	FullCombo bool
+	GhostTaps int
+	Failed    bool
+	Notes     []NoteResult // Urut sesuai waktu dinilai.
+}
+
+// Result merangkum run sampai saat ini.
+func (r *Run) Result() Result {
+	return Result{
+		Lanes:     r.Lanes,
+		Counts:    r.Counts(),
+		Points:    r.Points,
+		Score:     r.Rule.Score(),
+		Accuracy:  r.Rule.Accuracy(),
+		Grade:     r.Rule.Grade(),
+		MaxCombo:  r.MaxCombo,
+		FullCombo: r.FullCombo(),
+		GhostTaps: r.GhostTaps,
+		Failed:    r.Failed,
+		Notes:     r.Notes,
+	}
+}
+
+// Play menjalankan input berurutan seperti saat dimainkan: not yang lewat
+// dinilai miss sampai tick input, lalu tekanannya dinilai. Rewind hanya
+// menilai miss sampai tick asalnya.
+func (r *Run) Play(inputs []Input) {
+	for _, in := range inputs {
+		r.Advance(in.Tick, 0, nil)
+		if !in.Seek {
+			r.Press(in.Lane, in.Tick)
+		}
+	}
+}
+
+// Simulate memainkan chart dengan input sampai selesai tanpa window atau
+// audio, dan mengembalikan hasilnya.
+func Simulate(c *chart.Chart, cfg config.Config, inputs []Input) Result {
+	r := New(c, cfg)
+	r.Play(inputs)
+	r.Advance(math.Inf(1), 0, nil)
+	return r.Result()
+}
+
+// ReplayInputs mengambil tekanan dan rewind dari replay sesuai urutan
+// rekamannya. Not yang lewat sebelum rewind tetap dinilai miss, dan tekanan
+// setelahnya mengenai not yang sudah dinilai, sama seperti saat direkam.
+func ReplayInputs(r *replay.Replay) []Input {
+	var inputs []Input
+	for _, e := range r.Events {
+		switch e.Kind {
+		case replay.Press:
+			inputs = append(inputs, Input{Lane: int(e.Lane), Tick: e.Tick})
+		case replay.Seek:
+			inputs = append(inputs, Input{Tick: e.Tick, Seek: true})
+		}
+	}
+	return inputs
+}
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"image/color"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/rizalmf/old-boys/src/animations"
 )
@@ -18,6 +20,8 @@ type Char struct {
 	CenterX, CenterY float64
 
 	MarkImage       *ebiten.Image
+	MarkText        string      // Keterangan kecil di samping mark, mis. EARLY / LATE.
+	MarkTextColor   color.Color // Warna MarkText.
 	IsMark          bool
 	MarkTime        int
 	CurrentMarkTime int
@@ -0,0 +1,21 @@
+// Package device berisi sumber input.Source untuk keyboard, mouse, layar
+// sentuh dan gamepad lewat ebiten.
+package device
+
+import "github.com/rizalmf/old-boys/src/input"
+
+// held mencatat aksi yang ditahan dan menghasilkan event tekan/lepas saat
+// statusnya berubah.
+type held [input.ActionCount]bool
+
+// release menambahkan event lepas untuk aksi yang tidak lagi ditahan di now,
+// lalu menyimpan now sebagai status baru.
+func (h *held) release(now held, at float64, events []input.Event) []input.Event {
+	for a := range input.ActionCount {
+		if h[a] && !now[a] {
+			events = append(events, input.Event{Action: a, At: at})
+		}
+	}
+	*h = now
+	return events
+}
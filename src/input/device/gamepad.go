@@ -0,0 +1,124 @@
+package device
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/rizalmf/old-boys/src/input"
+)
+
+// ButtonNames adalah nama tombol gamepad layout standar (gaya Xbox) untuk
+// config dan layar tombol.
+var ButtonNames = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "A",
+	ebiten.StandardGamepadButtonRightRight:       "B",
+	ebiten.StandardGamepadButtonRightLeft:        "X",
+	ebiten.StandardGamepadButtonRightTop:         "Y",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "LB",
+	ebiten.StandardGamepadButtonFrontTopRight:    "RB",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "LT",
+	ebiten.StandardGamepadButtonFrontBottomRight: "RT",
+	ebiten.StandardGamepadButtonCenterLeft:       "Back",
+	ebiten.StandardGamepadButtonCenterRight:      "Start",
+	ebiten.StandardGamepadButtonLeftStick:        "LS",
+	ebiten.StandardGamepadButtonRightStick:       "RS",
+	ebiten.StandardGamepadButtonLeftTop:          "Up",
+	ebiten.StandardGamepadButtonLeftBottom:       "Down",
+	ebiten.StandardGamepadButtonLeftLeft:         "Left",
+	ebiten.StandardGamepadButtonLeftRight:        "Right",
+	ebiten.StandardGamepadButtonCenterCenter:     "Home",
+}
+
+// ParseButtons mengubah nama tombol dari config menjadi tombol gamepad.
+func ParseButtons(names []string) []ebiten.StandardGamepadButton {
+	var buttons []ebiten.StandardGamepadButton
+	for _, name := range names {
+		for b, n := range ButtonNames {
+			if n == name {
+				buttons = append(buttons, b)
+			}
+		}
+	}
+	return buttons
+}
+
+// Gamepad membaca semua gamepad layout standar yang tersambung dan
+// memetakan tombolnya ke aksi. Gamepad boleh dicabut dan dipasang kapan saja.
+type Gamepad struct {
+	bindings     map[ebiten.StandardGamepadButton][]input.Action
+	ids          []ebiten.GamepadID
+	held         held
+	connected    bool // Ada gamepad yang baru tersambung pada poll terakhir.
+	disconnected bool // Ada gamepad yang baru terputus pada poll terakhir.
+}
+
+func NewGamepad() *Gamepad {
+	return &Gamepad{bindings: make(map[ebiten.StandardGamepadButton][]input.Action)}
+}
+
+// SetBindings mengganti seluruh peta tombol.
+func (p *Gamepad) SetBindings(bindings map[ebiten.StandardGamepadButton][]input.Action) {
+	p.bindings = bindings
+}
+
+func (p *Gamepad) Poll(at float64, events []input.Event) []input.Event {
+	p.connected, p.disconnected = false, false
+	for _, id := range inpututil.AppendJustConnectedGamepadIDs(nil) {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			p.ids = append(p.ids, id)
+			p.connected = true
+		}
+	}
+	p.ids = slices.DeleteFunc(p.ids, func(id ebiten.GamepadID) bool {
+		gone := inpututil.IsGamepadJustDisconnected(id)
+		p.disconnected = p.disconnected || gone
+		return gone
+	})
+
+	var now held
+	for _, id := range p.ids {
+		for b, actions := range p.bindings {
+			if !ebiten.IsStandardGamepadButtonPressed(id, b) {
+				continue
+			}
+			just := inpututil.IsStandardGamepadButtonJustPressed(id, b)
+			for _, a := range actions {
+				now[a] = true
+				if just {
+					events = append(events, input.Event{Action: a, Pressed: true, At: at})
+				}
+			}
+		}
+	}
+	return p.held.release(now, at, events)
+}
+
+func (p *Gamepad) Held(a input.Action) bool {
+	return p.held[a]
+}
+
+// Count mengembalikan jumlah gamepad yang tersambung.
+func (p *Gamepad) Count() int {
+	return len(p.ids)
+}
+
+// JustConnected benar jika ada gamepad yang tersambung pada poll terakhir.
+func (p *Gamepad) JustConnected() bool {
+	return p.connected
+}
+
+// JustDisconnected benar jika ada gamepad yang terputus pada poll terakhir.
+func (p *Gamepad) JustDisconnected() bool {
+	return p.disconnected
+}
+
+// JustPressedButtons mengumpulkan tombol yang baru ditekan di semua gamepad,
+// tanpa peta aksi. Dipakai saat memilih tombol baru.
+func (p *Gamepad) JustPressedButtons() []ebiten.StandardGamepadButton {
+	var buttons []ebiten.StandardGamepadButton
+	for _, id := range p.ids {
+		buttons = inpututil.AppendJustPressedStandardGamepadButtons(id, buttons)
+	}
+	return buttons
+}
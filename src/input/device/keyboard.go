@@ -0,0 +1,44 @@
+package device
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/rizalmf/old-boys/src/input"
+)
+
+// Keyboard memetakan tombol keyboard ke aksi. Satu tombol boleh memicu
+// beberapa aksi, misalnya panah kiri untuk lajur dan navigasi menu.
+type Keyboard struct {
+	bindings map[ebiten.Key][]input.Action
+	held     held
+}
+
+func NewKeyboard() *Keyboard {
+	return &Keyboard{bindings: make(map[ebiten.Key][]input.Action)}
+}
+
+// SetBindings mengganti seluruh peta tombol.
+func (k *Keyboard) SetBindings(bindings map[ebiten.Key][]input.Action) {
+	k.bindings = bindings
+}
+
+func (k *Keyboard) Poll(at float64, events []input.Event) []input.Event {
+	var now held
+	for key, actions := range k.bindings {
+		if !ebiten.IsKeyPressed(key) {
+			continue
+		}
+		just := inpututil.IsKeyJustPressed(key)
+		for _, a := range actions {
+			now[a] = true
+			if just {
+				events = append(events, input.Event{Action: a, Pressed: true, At: at})
+			}
+		}
+	}
+	return k.held.release(now, at, events)
+}
+
+func (k *Keyboard) Held(a input.Action) bool {
+	return k.held[a]
+}
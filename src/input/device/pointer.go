@@ -0,0 +1,127 @@
+package device
+
+import (
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/rizalmf/old-boys/src/input"
+)
+
+// Zone adalah area layar yang memicu sebuah aksi saat disentuh atau diklik.
+type Zone struct {
+	Rect   image.Rectangle
+	Action input.Action
+}
+
+// zoneAt mengembalikan aksi zona di posisi layar.
+func zoneAt(zones []Zone, x, y int) (input.Action, bool) {
+	for _, z := range zones {
+		if image.Pt(x, y).In(z.Rect) {
+			return z.Action, true
+		}
+	}
+	return 0, false
+}
+
+// Mouse mengirim input.Tap untuk setiap klik kiri, ditambah aksi zona yang
+// diklik. Aksi zona ditahan sampai tombol mouse dilepas.
+type Mouse struct {
+	zones   []Zone
+	holding bool
+	action  input.Action
+}
+
+func NewMouse() *Mouse {
+	return &Mouse{}
+}
+
+func (m *Mouse) SetZones(zones []Zone) {
+	m.zones = zones
+}
+
+// Reset melupakan klik yang sedang ditahan.
+func (m *Mouse) Reset() {
+	m.holding = false
+}
+
+func (m *Mouse) Poll(at float64, events []input.Event) []input.Event {
+	if m.holding && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		m.holding = false
+		events = append(events, input.Event{Action: m.action, At: at})
+	}
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return events
+	}
+	x, y := ebiten.CursorPosition()
+	events = append(events, input.Event{Action: input.Tap, Pressed: true, At: at, X: x, Y: y})
+	if a, ok := zoneAt(m.zones, x, y); ok {
+		m.holding, m.action = true, a
+		events = append(events, input.Event{Action: a, Pressed: true, At: at, X: x, Y: y})
+	}
+	return events
+}
+
+func (m *Mouse) Held(a input.Action) bool {
+	return m.holding && m.action == a
+}
+
+// Touch mencatat setiap jari secara terpisah. Jari menekan zona tempat ia
+// menyentuh layar dan menahannya sampai diangkat, jadi beberapa jari bisa
+// memainkan chord.
+type Touch struct {
+	zones   []Zone
+	fingers map[ebiten.TouchID]input.Action
+	ids     []ebiten.TouchID
+}
+
+func NewTouch() *Touch {
+	return &Touch{fingers: make(map[ebiten.TouchID]input.Action)}
+}
+
+func (t *Touch) SetZones(zones []Zone) {
+	t.zones = zones
+}
+
+// Reset melupakan semua jari yang sedang ditahan.
+func (t *Touch) Reset() {
+	clear(t.fingers)
+}
+
+func (t *Touch) Poll(at float64, events []input.Event) []input.Event {
+	var before held
+	for _, a := range t.fingers {
+		before[a] = true
+	}
+	t.ids = ebiten.AppendTouchIDs(t.ids[:0])
+	for id := range t.fingers {
+		if !slices.Contains(t.ids, id) {
+			delete(t.fingers, id)
+		}
+	}
+
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		x, y := ebiten.TouchPosition(id)
+		events = append(events, input.Event{Action: input.Tap, Pressed: true, At: at, X: x, Y: y})
+		if a, ok := zoneAt(t.zones, x, y); ok {
+			t.fingers[id] = a
+			events = append(events, input.Event{Action: a, Pressed: true, At: at, X: x, Y: y})
+		}
+	}
+
+	var now held
+	for _, a := range t.fingers {
+		now[a] = true
+	}
+	return before.release(now, at, events)
+}
+
+func (t *Touch) Held(a input.Action) bool {
+	for _, action := range t.fingers {
+		if action == a {
+			return true
+		}
+	}
+	return false
+}
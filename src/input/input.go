@@ -0,0 +1,146 @@
+// Package input mengubah input dari berbagai perangkat menjadi aksi
+// permainan (lajur dan menu) beserta waktunya. Permainan dan menu membaca
+// aksi dari satu Manager, jadi skrip bisa menyuntikkan input tanpa perangkat.
+package input
+
+import "image"
+
+type Action uint8
+
+const (
+	Lane0 Action = iota
+	Lane1
+	Lane2
+	Up
+	Down
+	Left
+	Right
+	Confirm
+	Back
+	Pause
+	Settings
+	Clear    // Kosongkan pilihan, misalnya slot tombol.
+	Replay   // Putar replay terakhir.
+	Autoplay // Mainkan chart dengan bot.
+	Tap      // Sentuhan atau klik di mana saja; X dan Y berisi posisinya.
+
+	// Kecepatan replay 0.5x, 1x dan 2x.
+	HalfSpeed
+	NormalSpeed
+	DoubleSpeed
+	ActionCount
+)
+
+// LaneCount adalah jumlah aksi lajur.
+const LaneCount = 3
+
+// Lane mengembalikan aksi untuk lajur i.
+func Lane(i int) Action {
+	return Lane0 + Action(i)
+}
+
+// LaneIndex mengembalikan nomor lajur jika a adalah aksi lajur.
+func (a Action) LaneIndex() (int, bool) {
+	if a < Lane0+LaneCount {
+		return int(a - Lane0), true
+	}
+	return 0, false
+}
+
+// Event adalah satu aksi yang ditekan atau dilepas.
+type Event struct {
+	Action  Action
+	Pressed bool    // false = dilepas.
+	At      float64 // Waktu event dalam tick chart.
+	X, Y    int     // Posisi layar untuk input sentuh dan mouse.
+}
+
+// Source adalah satu sumber input: perangkat atau skrip.
+type Source interface {
+	// Poll menambahkan event sejak poll sebelumnya ke events. at adalah
+	// waktu sekarang; sumber perangkat memakainya sebagai waktu event.
+	Poll(at float64, events []Event) []Event
+	// Held cek apakah aksi sedang ditahan.
+	Held(a Action) bool
+}
+
+// Manager menggabungkan semua sumber input. Update dipanggil sekali per
+// frame, lalu hasilnya dibaca lewat JustPressed, Held dan Events.
+type Manager struct {
+	sources []Source
+	events  []Event
+	pressed [ActionCount]bool
+}
+
+func NewManager(sources ...Source) *Manager {
+	return &Manager{sources: sources}
+}
+
+// Add menambah sumber input, misalnya skrip untuk menyuntikkan input.
+func (m *Manager) Add(s Source) {
+	m.sources = append(m.sources, s)
+}
+
+func (m *Manager) Remove(s Source) {
+	for i, src := range m.sources {
+		if src == s {
+			m.sources = append(m.sources[:i], m.sources[i+1:]...)
+			return
+		}
+	}
+}
+
+// Update membaca event baru dari semua sumber pada waktu at.
+func (m *Manager) Update(at float64) {
+	m.events = m.events[:0]
+	for _, s := range m.sources {
+		m.events = s.Poll(at, m.events)
+	}
+	m.pressed = [ActionCount]bool{}
+	for _, e := range m.events {
+		if e.Pressed {
+			m.pressed[e.Action] = true
+		}
+	}
+}
+
+// Events mengembalikan event frame ini sesuai urutan sumbernya.
+func (m *Manager) Events() []Event {
+	return m.events
+}
+
+// JustPressed cek apakah aksi ditekan pada frame ini.
+func (m *Manager) JustPressed(a Action) bool {
+	return m.pressed[a]
+}
+
+// Held cek apakah aksi sedang ditahan di salah satu sumber.
+func (m *Manager) Held(a Action) bool {
+	for _, s := range m.sources {
+		if s.Held(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// Any cek apakah ada aksi apa pun yang ditekan pada frame ini.
+func (m *Manager) Any() bool {
+	for _, e := range m.events {
+		if e.Pressed {
+			return true
+		}
+	}
+	return false
+}
+
+// Taps mengembalikan posisi setiap sentuhan atau klik baru pada frame ini.
+func (m *Manager) Taps() []image.Point {
+	var points []image.Point
+	for _, e := range m.events {
+		if e.Action == Tap && e.Pressed {
+			points = append(points, image.Pt(e.X, e.Y))
+		}
+	}
+	return points
+}
@@ -0,0 +1,94 @@
+package input
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestLaneIndex(t *testing.T) {
+	for i := range LaneCount {
+		if got, ok := Lane(i).LaneIndex(); !ok || got != i {
+			t.Errorf("Lane(%d).LaneIndex() = %d, %v", i, got, ok)
+		}
+	}
+	for _, a := range []Action{Up, Confirm, Tap, DoubleSpeed} {
+		if _, ok := a.LaneIndex(); ok {
+			t.Errorf("action %d is a lane", a)
+		}
+	}
+}
+
+func TestManagerMergesSources(t *testing.T) {
+	keys := NewScript([]Event{
+		{Action: Lane0, Pressed: true, At: 1},
+		{Action: Confirm, Pressed: true, At: 2},
+	})
+	touch := NewScript([]Event{
+		{Action: Tap, Pressed: true, At: 1, X: 10, Y: 20},
+		{Action: Lane2, Pressed: true, At: 1},
+		{Action: Tap, Pressed: false, At: 3},
+	})
+	m := NewManager(keys, touch)
+
+	m.Update(1)
+	want := []Event{
+		{Action: Lane0, Pressed: true, At: 1},
+		{Action: Tap, Pressed: true, At: 1, X: 10, Y: 20},
+		{Action: Lane2, Pressed: true, At: 1},
+	}
+	if !reflect.DeepEqual(m.Events(), want) {
+		t.Errorf("Events = %v, want %v", m.Events(), want)
+	}
+	if !m.JustPressed(Lane0) || !m.JustPressed(Lane2) || m.JustPressed(Confirm) {
+		t.Error("JustPressed does not match the events of this frame")
+	}
+	if got := m.Taps(); !reflect.DeepEqual(got, []image.Point{image.Pt(10, 20)}) {
+		t.Errorf("Taps = %v", got)
+	}
+
+	m.Update(2)
+	if m.JustPressed(Lane0) || !m.JustPressed(Confirm) {
+		t.Error("JustPressed kept last frame's presses")
+	}
+	if !m.Held(Lane0) || !m.Held(Tap) {
+		t.Error("Held lost actions that were not released")
+	}
+	if m.Taps() != nil {
+		t.Errorf("Taps = %v with no new tap", m.Taps())
+	}
+
+	m.Update(3)
+	if m.Any() {
+		t.Error("Any with only a release this frame")
+	}
+	if m.Held(Tap) {
+		t.Error("Tap still held after its release")
+	}
+}
+
+func TestManagerAddRemove(t *testing.T) {
+	first := NewScript([]Event{{Action: Lane1, Pressed: true, At: 1}})
+	second := NewScript([]Event{{Action: Back, Pressed: true, At: 1}})
+	m := NewManager()
+
+	m.Update(0)
+	if m.Any() || m.Held(Lane1) {
+		t.Error("Manager without sources reports input")
+	}
+
+	m.Add(first)
+	m.Add(second)
+	m.Remove(first)
+	m.Update(1)
+	if m.JustPressed(Lane1) || m.Held(Lane1) {
+		t.Error("removed source is still polled")
+	}
+	if !m.JustPressed(Back) {
+		t.Error("added source is not polled")
+	}
+	if first.Done() {
+		t.Error("removed source was polled")
+	}
+	m.Remove(first) // Tidak terpasang: tidak apa-apa.
+}
@@ -0,0 +1,47 @@
+package input
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Script adalah sumber input terjadwal: setiap event dikirim saat waktunya
+// tiba, dengan waktu aslinya. Dipakai untuk menyuntikkan input tanpa
+// perangkat.
+type Script struct {
+	events []Event
+	next   int
+	held   [ActionCount]int
+}
+
+// NewScript membuat skrip dari events; urutannya diatur berdasarkan At.
+func NewScript(events []Event) *Script {
+	events = slices.Clone(events)
+	slices.SortStableFunc(events, func(a, b Event) int {
+		return cmp.Compare(a.At, b.At)
+	})
+	return &Script{events: events}
+}
+
+func (s *Script) Poll(at float64, events []Event) []Event {
+	for s.next < len(s.events) && s.events[s.next].At <= at {
+		e := s.events[s.next]
+		s.next++
+		if e.Pressed {
+			s.held[e.Action]++
+		} else if s.held[e.Action] > 0 {
+			s.held[e.Action]--
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func (s *Script) Held(a Action) bool {
+	return s.held[a] > 0
+}
+
+// Done benar jika semua event sudah dikirim.
+func (s *Script) Done() bool {
+	return s.next == len(s.events)
+}
@@ -0,0 +1,67 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScriptSendsEventsOnTime(t *testing.T) {
+	events := []Event{
+		{Action: Lane1, Pressed: true, At: 30},
+		{Action: Lane0, Pressed: true, At: 10},
+		{Action: Lane0, Pressed: false, At: 20},
+		{Action: Lane2, Pressed: true, At: 10},
+	}
+	s := NewScript(events)
+	events[0].At = 0 // Skrip memakai salinannya sendiri.
+
+	for _, tt := range []struct {
+		at   float64
+		want []Event
+	}{
+		{5, nil},
+		{10, []Event{{Action: Lane0, Pressed: true, At: 10}, {Action: Lane2, Pressed: true, At: 10}}},
+		{10, nil},
+		{25.5, []Event{{Action: Lane0, Pressed: false, At: 20}}},
+		{100, []Event{{Action: Lane1, Pressed: true, At: 30}}},
+	} {
+		if got := s.Poll(tt.at, nil); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Poll(%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+	if !s.Done() {
+		t.Error("Done = false after every event was sent")
+	}
+}
+
+func TestScriptHeld(t *testing.T) {
+	s := NewScript([]Event{
+		{Action: Lane0, Pressed: true, At: 1},
+		{Action: Lane0, Pressed: true, At: 2}, // Jari kedua di lajur yang sama.
+		{Action: Lane0, Pressed: false, At: 3},
+		{Action: Lane0, Pressed: false, At: 4},
+		{Action: Lane1, Pressed: false, At: 4}, // Lepas tanpa tekan diabaikan.
+	})
+	for _, tt := range []struct {
+		at   float64
+		held bool
+	}{{0, false}, {1, true}, {2, true}, {3, true}, {4, false}} {
+		s.Poll(tt.at, nil)
+		if s.Held(Lane0) != tt.held {
+			t.Errorf("at %v: Held(Lane0) = %v, want %v", tt.at, !tt.held, tt.held)
+		}
+	}
+	if s.Held(Lane1) {
+		t.Error("Held(Lane1) after a release without a press")
+	}
+}
+
+func TestScriptAppends(t *testing.T) {
+	s := NewScript([]Event{{Action: Confirm, Pressed: true, At: 1}})
+	prev := []Event{{Action: Up, Pressed: true, At: 1}}
+	got := s.Poll(1, prev)
+	want := []Event{{Action: Up, Pressed: true, At: 1}, {Action: Confirm, Pressed: true, At: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Poll = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+package judge
+
+// weights adalah bobot akurasi setiap tingkat penilaian, dari 0 sampai 1.
+var weights = [JudgementCount]float64{
+	Perfect: 1,
+	Great:   0.75,
+	Good:    0.5,
+	Bad:     0.2,
+	Miss:    0,
+}
+
+// Accuracy menghitung akurasi berbobot (0-100) dari jumlah setiap penilaian.
+func Accuracy(counts [JudgementCount]int) float64 {
+	total, sum := 0, 0.0
+	for j, n := range counts {
+		total += n
+		sum += float64(n) * weights[j]
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / float64(total) * 100
+}
+
+type Grade int8
+
+const (
+	GradeS Grade = iota
+	GradeA
+	GradeB
+	GradeC
+	GradeD
+)
+
+func (g Grade) String() string {
+	switch g {
+	case GradeS:
+		return "S"
+	case GradeA:
+		return "A"
+	case GradeB:
+		return "B"
+	case GradeC:
+		return "C"
+	}
+	return "D"
+}
+
+// Thresholds adalah akurasi minimum (persen) untuk setiap grade. Di bawah C
+// mendapat D.
+type Thresholds struct {
+	S float64
+	A float64
+	B float64
+	C float64
+}
+
+func DefaultThresholds() Thresholds {
+	return Thresholds{S: 95, A: 90, B: 80, C: 70}
+}
+
+// Grade mengubah akurasi menjadi huruf grade.
+func (t Thresholds) Grade(accuracy float64) Grade {
+	switch {
+	case accuracy >= t.S:
+		return GradeS
+	case accuracy >= t.A:
+		return GradeA
+	case accuracy >= t.B:
+		return GradeB
+	case accuracy >= t.C:
+		return GradeC
+	}
+	return GradeD
+}
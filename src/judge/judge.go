@@ -0,0 +1,83 @@
+package judge
+
+type Judgement int8
+
+const (
+	Perfect Judgement = iota
+	Great
+	Good
+	Bad
+	Miss
+	JudgementCount
+)
+
+func (j Judgement) String() string {
+	switch j {
+	case Perfect:
+		return "PERFECT"
+	case Great:
+		return "GREAT"
+	case Good:
+		return "GOOD"
+	case Bad:
+		return "BAD"
+	}
+	return "MISS"
+}
+
+// Windows adalah batas selisih waktu (dalam milidetik, ke depan maupun ke
+// belakang) untuk setiap tingkat penilaian.
+type Windows struct {
+	Perfect float64
+	Great   float64
+	Good    float64
+	Bad     float64
+}
+
+// Judge menilai selisih waktu tekan terhadap not. ok bernilai false jika
+// selisihnya di luar semua window, artinya tekanan itu tidak mengenai not.
+func (w Windows) Judge(offsetMs float64) (j Judgement, ok bool) {
+	if offsetMs < 0 {
+		offsetMs = -offsetMs
+	}
+	switch {
+	case offsetMs <= w.Perfect:
+		return Perfect, true
+	case offsetMs <= w.Great:
+		return Great, true
+	case offsetMs <= w.Good:
+		return Good, true
+	case offsetMs <= w.Bad:
+		return Bad, true
+	}
+	return Miss, false
+}
+
+type Preset int8
+
+const (
+	PresetStandard Preset = iota
+	PresetStrict
+	PresetLenient
+	PresetCount
+)
+
+func (p Preset) String() string {
+	switch p {
+	case PresetStrict:
+		return "Strict"
+	case PresetLenient:
+		return "Lenient"
+	}
+	return "Standard"
+}
+
+func (p Preset) Windows() Windows {
+	switch p {
+	case PresetStrict:
+		return Windows{Perfect: 25, Great: 50, Good: 85, Bad: 120}
+	case PresetLenient:
+		return Windows{Perfect: 60, Great: 110, Good: 160, Bad: 200}
+	}
+	return Windows{Perfect: 40, Great: 80, Good: 120, Bad: 160}
+}
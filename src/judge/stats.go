@@ -0,0 +1,34 @@
+package judge
+
+import "math"
+
+// Stats menghitung rata-rata dan simpangan baku selisih waktu tekan (ms).
+// Nilai negatif berarti terlalu cepat, positif terlalu lambat.
+func Stats(offsets []float64) (mean, stddev float64) {
+	if len(offsets) == 0 {
+		return 0, 0
+	}
+	for _, o := range offsets {
+		mean += o
+	}
+	mean /= float64(len(offsets))
+	for _, o := range offsets {
+		stddev += (o - mean) * (o - mean)
+	}
+	stddev = math.Sqrt(stddev / float64(len(offsets)))
+	return mean, stddev
+}
+
+// Histogram membagi selisih waktu dalam rentang [-limit, limit] ke sejumlah
+// bins yang sama lebar. Nilai di luar rentang masuk ke bin paling ujung.
+func Histogram(offsets []float64, bins int, limit float64) []int {
+	h := make([]int, bins)
+	if bins == 0 || limit <= 0 {
+		return h
+	}
+	for _, o := range offsets {
+		i := int((o + limit) / (2 * limit) * float64(bins))
+		h[min(max(i, 0), bins-1)]++
+	}
+	return h
+}
@@ -1,5 +1,7 @@
 package lang
 
+import "fmt"
+
 type EN struct {
 }
 
@@ -8,7 +10,31 @@ func (l *EN) GetLang() string {
 }
 
 func (l *EN) BackToMenu() string {
-	return "Press Esc to back main menu"
+	return "Back to main menu"
+}
+
+func (l *EN) MaxCombo() string {
+	return "MAX COMBO"
+}
+
+func (l *EN) FullCombo() string {
+	return "FULL COMBO"
+}
+
+func (l *EN) Failed() string {
+	return "FAILED"
+}
+
+func (l *EN) GhostTaps() string {
+	return "GHOST TAP"
+}
+
+func (l *EN) ControllerConnected() string {
+	return "Controller connected"
+}
+
+func (l *EN) ControllerDisconnected() string {
+	return "Controller disconnected"
 }
 
 // MAIN MENU
@@ -16,4 +42,138 @@ func (l *EN) Start() string {
 	return "Start"
 }
 
+func (l *EN) Replay() string {
+	return "Replay (R / drop .obr)"
+}
+
+func (l *EN) Autoplay() string {
+	return "Autoplay"
+}
+
+// SETTINGS
+func (l *EN) Settings() string {
+	return "Settings"
+}
+
+func (l *EN) SkipIntro() string {
+	return "Skip intro"
+}
+
+func (l *EN) Metronome() string {
+	return "Metronome"
+}
+
+func (l *EN) SongRate() string {
+	return "Song rate"
+}
+
+func (l *EN) Judgement() string {
+	return "Judgement"
+}
+
+func (l *EN) ComboMultiplier() string {
+	return "Combo Multiplier"
+}
+
+func (l *EN) NoFail() string {
+	return "No Fail"
+}
+
+func (l *EN) GhostTap() string {
+	return "Ghost Tap Penalty"
+}
+
+func (l *EN) Scoring() string {
+	return "Scoring"
+}
+
+func (l *EN) Ghost() string {
+	return "Ghost Opponent"
+}
+
+func (l *EN) AutoplayJitter() string {
+	return "Autoplay Jitter"
+}
+
+func (l *EN) KeyBindings() string {
+	return "Key Bindings"
+}
+
+func (l *EN) TouchLayout() string {
+	return "Touch Layout"
+}
+
+func (l *EN) TouchZones() string {
+	return "Show Touch Zones"
+}
+
+func (l *EN) PressKey() string {
+	return "Press a key (Esc to cancel)"
+}
+
+func (l *EN) KeyReserved(key string) string {
+	return fmt.Sprintf("%s is reserved for menus", key)
+}
+
+func (l *EN) LaneName(lane int) string {
+	return [...]string{"Guitar", "Drums", "Bass"}[lane]
+}
+
+func (l *EN) OnlyKey(key, lane string) string {
+	return fmt.Sprintf("%s is the only key for %s", key, lane)
+}
+
+func (l *EN) KeySwapped(key, lane string) string {
+	return fmt.Sprintf("%s was used by %s, swapped", key, lane)
+}
+
+func (l *EN) NeedsKey(lane string) string {
+	return fmt.Sprintf("%s needs at least one key", lane)
+}
+
+func (l *EN) Keyboard() string {
+	return "Keyboard"
+}
+
+func (l *EN) Gamepad(count int) string {
+	return fmt.Sprintf("Gamepad (%d)", count)
+}
+
+func (l *EN) KeysHint() string {
+	return "Enter/A: rebind   Backspace/X: clear   Esc/B: back"
+}
+
+func (l *EN) On() string {
+	return "On"
+}
+
+func (l *EN) Off() string {
+	return "Off"
+}
+
+func (l *EN) NoTempo() string {
+	return "No tempo"
+}
+
+func (l *EN) Back() string {
+	return "Back"
+}
+
+// PAUSE
+func (l *EN) Paused() string {
+	return "Paused"
+}
+
+func (l *EN) Resume() string {
+	return "Resume"
+}
+
+func (l *EN) Restart() string {
+	return "Restart"
+}
+
+func (l *EN) ResumeHint() string {
+	return "Press Esc to resume"
+}
+
 var _ Lang = (*EN)(nil)
@@ -1,5 +1,7 @@
 package lang
 
+import "fmt"
+
 type ID struct {
 }
 
@@ -8,7 +10,31 @@ func (l *ID) GetLang() string {
 }
 
 func (l *ID) BackToMenu() string {
-	return "Tekan 'Esc' untuk kembali"
+	return "Kembali ke menu utama"
+}
+
+func (l *ID) MaxCombo() string {
+	return "COMBO MAKS"
+}
+
+func (l *ID) FullCombo() string {
+	return "COMBO PENUH"
+}
+
+func (l *ID) Failed() string {
+	return "GAGAL"
+}
+
+func (l *ID) GhostTaps() string {
+	return "TAP KOSONG"
+}
+
+func (l *ID) ControllerConnected() string {
+	return "Kontroler tersambung"
+}
+
+func (l *ID) ControllerDisconnected() string {
+	return "Kontroler terputus"
 }
 
 // MAIN MENU
@@ -16,4 +42,138 @@ func (l *ID) Start() string {
 	return "Mulai"
 }
 
+func (l *ID) Replay() string {
+	return "Replay (R / seret .obr)"
+}
+
+func (l *ID) Autoplay() string {
+	return "Main Otomatis"
+}
+
+// SETTINGS
+func (l *ID) Settings() string {
+	return "Pengaturan"
+}
+
+func (l *ID) SkipIntro() string {
+	return "Lewati intro"
+}
+
+func (l *ID) Metronome() string {
+	return "Metronom"
+}
+
+func (l *ID) SongRate() string {
+	return "Kecepatan lagu"
+}
+
+func (l *ID) Judgement() string {
+	return "Penilaian"
+}
+
+func (l *ID) ComboMultiplier() string {
+	return "Pengali Combo"
+}
+
+func (l *ID) NoFail() string {
+	return "Tanpa Gagal"
+}
+
+func (l *ID) GhostTap() string {
+	return "Hukuman Tap Kosong"
+}
+
+func (l *ID) Scoring() string {
+	return "Aturan Skor"
+}
+
+func (l *ID) Ghost() string {
+	return "Lawan Ghost"
+}
+
+func (l *ID) AutoplayJitter() string {
+	return "Jitter Autoplay"
+}
+
+func (l *ID) KeyBindings() string {
+	return "Tombol Lajur"
+}
+
+func (l *ID) TouchLayout() string {
+	return "Tata Letak Sentuh"
+}
+
+func (l *ID) TouchZones() string {
+	return "Tampilkan Area Sentuh"
+}
+
+func (l *ID) PressKey() string {
+	return "Tekan tombol (Esc untuk batal)"
+}
+
+func (l *ID) KeyReserved(key string) string {
+	return fmt.Sprintf("%s dipakai untuk menu", key)
+}
+
+func (l *ID) LaneName(lane int) string {
+	return [...]string{"Gitar", "Drum", "Bass"}[lane]
+}
+
+func (l *ID) OnlyKey(key, lane string) string {
+	return fmt.Sprintf("%s satu-satunya tombol %s", key, lane)
+}
+
+func (l *ID) KeySwapped(key, lane string) string {
+	return fmt.Sprintf("%s tadinya dipakai %s, ditukar", key, lane)
+}
+
+func (l *ID) NeedsKey(lane string) string {
+	return fmt.Sprintf("%s butuh minimal satu tombol", lane)
+}
+
+func (l *ID) Keyboard() string {
+	return "Keyboard"
+}
+
+func (l *ID) Gamepad(count int) string {
+	return fmt.Sprintf("Gamepad (%d)", count)
+}
+
+func (l *ID) KeysHint() string {
+	return "Enter/A: ganti   Backspace/X: kosongkan   Esc/B: kembali"
+}
+
+func (l *ID) On() string {
+	return "Nyala"
+}
+
+func (l *ID) Off() string {
+	return "Mati"
+}
+
+func (l *ID) NoTempo() string {
+	return "Tanpa tempo"
+}
+
+func (l *ID) Back() string {
+	return "Kembali"
+}
+
+// PAUSE
+func (l *ID) Paused() string {
+	return "Jeda"
+}
+
+func (l *ID) Resume() string {
+	return "Lanjut"
+}
+
+func (l *ID) Restart() string {
+	return "Ulangi"
+}
+
+func (l *ID) ResumeHint() string {
+	return "Tekan 'Esc' untuk lanjut"
+}
+
 var _ Lang = (*ID)(nil)
@@ -4,9 +4,52 @@ type Lang interface {
 	GetLang() string
 	// MAIN MENU
 	Start() string
+	Replay() string
+	Autoplay() string
+
+	// SETTINGS
+	Settings() string
+	SkipIntro() string
+	Metronome() string
+	SongRate() string
+	Judgement() string
+	ComboMultiplier() string
+	NoFail() string
+	GhostTap() string
+	Scoring() string
+	Ghost() string
+	AutoplayJitter() string
+	KeyBindings() string
+	TouchLayout() string
+	TouchZones() string
+	PressKey() string
+	KeyReserved(key string) string
+	LaneName(lane int) string
+	OnlyKey(key, lane string) string
+	KeySwapped(key, lane string) string
+	NeedsKey(lane string) string
+	Keyboard() string
+	Gamepad(count int) string
+	KeysHint() string
+	On() string
+	Off() string
+	NoTempo() string
+	Back() string
 
 	// Game
 	BackToMenu() string
+	MaxCombo() string
+	FullCombo() string
+	Failed() string
+	GhostTaps() string
+	ControllerConnected() string
+	ControllerDisconnected() string
+
+	// PAUSE
+	Paused() string
+	Resume() string
+	Restart() string
+	ResumeHint() string
 }
 
 type Language uint
@@ -0,0 +1,50 @@
+package replay
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/rizalmf/old-boys/src/chart"
+	"github.com/rizalmf/old-boys/src/config"
+)
+
+// autoplayHold adalah lama (tick) bot menahan lajur setelah menekan.
+const autoplayHold = 10
+
+// Autoplay membuat replay yang menekan setiap not di chart. jitter adalah
+// simpangan baku (ms waktu nyata) timing tekanan supaya terlihat seperti
+// dimainkan manusia; 0 berarti setiap not ditekan tepat pada tick-nya.
+func Autoplay(c *chart.Chart, cfg config.Config, jitter float64, rng *rand.Rand) *Replay {
+	notes := slices.Clone(c.Notes)
+	slices.SortStableFunc(notes, func(a, b *chart.Note) int {
+		return cmp.Compare(a.Tick, b.Tick)
+	})
+	presses := make([]float64, len(notes))
+	for i, note := range notes {
+		offset := 0.0
+		if jitter > 0 {
+			offset = min(max(rng.NormFloat64(), -3), 3) * jitter
+		}
+		presses[i] = Quantize(note.Tick + offset/1000*chart.TicksPerSec*cfg.SongRate)
+	}
+
+	r := New(c.Hash, c.Difficulty, cfg)
+	for i, note := range notes {
+		// Lepas sebelum not berikutnya di lajur yang sama ditekan.
+		release := presses[i] + autoplayHold
+		for j := i + 1; j < len(notes); j++ {
+			if notes[j].Lane == note.Lane {
+				release = min(release, presses[j]-1.0/TickUnits)
+				break
+			}
+		}
+		r.Press(int(note.Lane), presses[i])
+		r.Release(int(note.Lane), Quantize(max(release, presses[i])))
+	}
+
+	slices.SortStableFunc(r.Events, func(a, b Event) int {
+		return cmp.Compare(a.Tick, b.Tick)
+	})
+	return r
+}
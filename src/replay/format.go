@@ -0,0 +1,257 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"time"
+
+	"github.com/rizalmf/old-boys/src/config"
+	"github.com/rizalmf/old-boys/src/judge"
+)
+
+// Format file replay (little endian, angka bervariasi memakai varint):
+//
+//	magic "OBRP", versi (1 byte), hash chart (32 byte), tingkat kesulitan,
+//	tanggal (unix ms), skor, akurasi, modifier, jumlah event, event,
+//	CRC-32 dari semua byte sebelumnya (4 byte).
+//
+// Tick event disimpan sebagai selisih dari event sebelumnya dalam satuan
+// 1/TickUnits tick, jadi file kecil dan tick yang dibaca sama persis.
+const (
+	magic   = "OBRP"
+	version = 1
+
+	// TickUnits adalah resolusi tick replay; tick dibulatkan ke 1/TickUnits.
+	TickUnits = 1000
+
+	maxStringLen = 256
+)
+
+const (
+	flagSkipIntro = 1 << iota
+	flagComboMultiplier
+	flagNoFail
+	flagGhostTap
+)
+
+var (
+	ErrFormat   = errors.New("replay: not a replay file")
+	ErrVersion  = errors.New("replay: unsupported version")
+	ErrChecksum = errors.New("replay: checksum mismatch")
+	ErrCorrupt  = errors.New("replay: corrupt data")
+)
+
+// Quantize membulatkan tick ke resolusi replay. Input yang direkam dan dinilai
+// memakai tick ini agar hasil replay sama persis dengan run aslinya.
+func Quantize(tick float64) float64 {
+	return float64(units(tick)) / TickUnits
+}
+
+func units(tick float64) int64 {
+	return int64(math.Round(tick * TickUnits))
+}
+
+// Marshal mengubah replay menjadi file biner.
+func Marshal(r *Replay) ([]byte, error) {
+	hash, err := hex.DecodeString(r.ChartHash)
+	if err != nil || len(hash) != 32 {
+		return nil, fmt.Errorf("replay: invalid chart hash %q", r.ChartHash)
+	}
+	if len(r.Difficulty) > maxStringLen || len(r.Config.Scoring) > maxStringLen {
+		return nil, errors.New("replay: string too long")
+	}
+
+	var b []byte
+	b = append(b, magic...)
+	b = append(b, version)
+	b = append(b, hash...)
+	b = appendString(b, r.Difficulty)
+	b = binary.AppendVarint(b, r.Date.UnixMilli())
+	b = binary.AppendVarint(b, int64(r.Score))
+	b = appendFloat(b, r.Accuracy)
+
+	c := r.Config
+	var flags byte
+	if c.SkipIntro {
+		flags |= flagSkipIntro
+	}
+	if c.ComboMultiplier {
+		flags |= flagComboMultiplier
+	}
+	if c.NoFail {
+		flags |= flagNoFail
+	}
+	if c.GhostTapPenalty {
+		flags |= flagGhostTap
+	}
+	b = append(b, flags, byte(c.Judgement))
+	b = appendFloat(b, c.SongRate)
+	b = appendFloat(b, c.MetronomeVolume)
+	for _, v := range []float64{c.Grades.S, c.Grades.A, c.Grades.B, c.Grades.C} {
+		b = appendFloat(b, v)
+	}
+	b = appendString(b, c.Scoring)
+
+	b = binary.AppendUvarint(b, uint64(len(r.Events)))
+	var prev int64
+	for _, e := range r.Events {
+		if e.Kind > Seek || e.Lane > 0x0f {
+			return nil, fmt.Errorf("replay: invalid event %+v", e)
+		}
+		t := units(e.Tick)
+		b = append(b, byte(e.Kind)<<4|e.Lane)
+		b = binary.AppendVarint(b, t-prev)
+		if e.Kind == Seek {
+			b = binary.AppendVarint(b, units(e.To)-t)
+		}
+		prev = t
+	}
+
+	return binary.LittleEndian.AppendUint32(b, crc32.ChecksumIEEE(b)), nil
+}
+
+// Unmarshal membaca file biner replay dan memeriksa checksum-nya.
+func Unmarshal(data []byte) (*Replay, error) {
+	if len(data) < len(magic)+1+4 || !bytes.HasPrefix(data, []byte(magic)) {
+		return nil, ErrFormat
+	}
+	if data[len(magic)] != version {
+		return nil, ErrVersion
+	}
+	body, sum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != sum {
+		return nil, ErrChecksum
+	}
+
+	d := &decoder{b: body[len(magic)+1:]}
+	r := &Replay{}
+	r.ChartHash = hex.EncodeToString(d.bytes(32))
+	r.Difficulty = d.string()
+	r.Date = time.UnixMilli(d.varint())
+	r.Score = int(d.varint())
+	r.Accuracy = d.float()
+
+	flags := d.byte()
+	r.Config = config.Config{
+		SkipIntro:       flags&flagSkipIntro != 0,
+		ComboMultiplier: flags&flagComboMultiplier != 0,
+		NoFail:          flags&flagNoFail != 0,
+		GhostTapPenalty: flags&flagGhostTap != 0,
+		Judgement:       judge.Preset(d.byte()),
+		SongRate:        d.float(),
+		MetronomeVolume: d.float(),
+	}
+	r.Config.Grades = judge.Thresholds{S: d.float(), A: d.float(), B: d.float(), C: d.float()}
+	r.Config.Scoring = d.string()
+	if r.Config.Judgement >= judge.PresetCount {
+		return nil, ErrCorrupt
+	}
+
+	n := d.uvarint()
+	// Setiap event paling sedikit 2 byte; tolak jumlah yang mustahil sebelum
+	// mengalokasikan slice.
+	if n > uint64(len(d.b))/2 {
+		return nil, ErrCorrupt
+	}
+	r.Events = make([]Event, 0, n)
+	var prev int64
+	for range n {
+		head := d.byte()
+		e := Event{Kind: EventKind(head >> 4), Lane: head & 0x0f}
+		if e.Kind > Seek {
+			return nil, ErrCorrupt
+		}
+		t := prev + d.varint()
+		e.Tick = float64(t) / TickUnits
+		if e.Kind == Seek {
+			e.To = float64(t+d.varint()) / TickUnits
+		}
+		r.Events = append(r.Events, e)
+		prev = t
+	}
+
+	if d.err != nil || len(d.b) != 0 {
+		return nil, ErrCorrupt
+	}
+	return r, nil
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+func appendFloat(b []byte, v float64) []byte {
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+}
+
+// decoder membaca field satu per satu. Setelah error pertama semua bacaan
+// mengembalikan nilai nol, jadi error cukup dicek sekali di akhir.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) fail() {
+	d.err = ErrCorrupt
+	d.b = nil
+}
+
+func (d *decoder) bytes(n int) []byte {
+	if d.err != nil || len(d.b) < n {
+		d.fail()
+		return make([]byte, n)
+	}
+	v := d.b[:n]
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *decoder) byte() byte {
+	return d.bytes(1)[0]
+}
+
+// float membaca float64. NaN dan tak hingga tidak pernah ditulis, jadi
+// dianggap rusak.
+func (d *decoder) float() float64 {
+	v := math.Float64frombits(binary.LittleEndian.Uint64(d.bytes(8)))
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		d.fail()
+		return 0
+	}
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *decoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.b)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *decoder) string() string {
+	n := d.uvarint()
+	if n > maxStringLen {
+		d.fail()
+		return ""
+	}
+	return string(d.bytes(int(n)))
+}
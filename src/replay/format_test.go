@@ -0,0 +1,209 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rizalmf/old-boys/assets/notes"
+	"github.com/rizalmf/old-boys/src/chart"
+	"github.com/rizalmf/old-boys/src/config"
+	"github.com/rizalmf/old-boys/src/judge"
+)
+
+const testHash = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+
+// recording membuat replay nyata: autoplay pada chart bawaan dengan jitter.
+func recording(t testing.TB) *Replay {
+	c, err := chart.Parse(notes.Note_json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Autoplay(c, config.Default(), 20, rand.New(rand.NewPCG(1, 2)))
+	r.Seek(Quantize(1500.1234), Quantize(1300.5678))
+	return r
+}
+
+func marshal(t testing.TB, r *Replay) []byte {
+	data, err := Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// withChecksum mengganti CRC di akhir data supaya kerusakan di badan file
+// sampai ke decoder.
+func withChecksum(body []byte) []byte {
+	body = bytes.Clone(body)
+	return binary.LittleEndian.AppendUint32(body, crc32.ChecksumIEEE(body))
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	cfg := config.Default()
+	cfg.SkipIntro = true
+	cfg.NoFail = true
+	cfg.GhostTapPenalty = true
+	cfg.Judgement = judge.PresetStrict
+	cfg.SongRate = 1.3
+	cfg.MetronomeVolume = 0.4
+	cfg.Scoring = "ex"
+
+	r := New(testHash, "Hard", cfg)
+	r.Score = 12345
+	r.Accuracy = 97.25
+	r.Date = time.UnixMilli(1_700_000_000_123)
+	ticks := []float64{-12.3456, 0, 0.0004, 0.0006, 917.78823, 917.78823, 1022.5449, 123456.789}
+	for i, tick := range ticks {
+		r.Press(i%3, Quantize(tick))
+		r.Release(i%3, Quantize(tick+7.0001))
+	}
+	r.Seek(Quantize(1500.1234), Quantize(1300.5678))
+	r.Press(2, Quantize(1300.6))
+
+	got, err := Unmarshal(marshal(t, r))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ChartHash != r.ChartHash || got.Difficulty != r.Difficulty || got.Score != r.Score ||
+		got.Accuracy != r.Accuracy || !got.Date.Equal(r.Date) {
+		t.Errorf("header = %+v, want %+v", got, r)
+	}
+	c := got.Config
+	if c.SkipIntro != cfg.SkipIntro || c.NoFail != cfg.NoFail || c.GhostTapPenalty != cfg.GhostTapPenalty ||
+		c.ComboMultiplier != cfg.ComboMultiplier || c.Judgement != cfg.Judgement || c.SongRate != cfg.SongRate ||
+		c.MetronomeVolume != cfg.MetronomeVolume || c.Grades != cfg.Grades || c.Scoring != cfg.Scoring {
+		t.Errorf("config = %+v, want %+v", c, cfg)
+	}
+	if len(got.Events) != len(r.Events) {
+		t.Fatalf("got %d events, want %d", len(got.Events), len(r.Events))
+	}
+	for i, e := range r.Events {
+		if got.Events[i] != e {
+			t.Errorf("event %d = %+v, want %+v", i, got.Events[i], e)
+		}
+	}
+}
+
+func TestUnmarshalRejects(t *testing.T) {
+	data := marshal(t, recording(t))
+	body := data[:len(data)-4]
+
+	badMagic := bytes.Clone(data)
+	badMagic[0] = 'X'
+	badVersion := withChecksum(append([]byte(magic), append([]byte{version + 1}, body[len(magic)+1:]...)...))
+	badCRC := bytes.Clone(data)
+	badCRC[len(badCRC)-1] ^= 0xff
+	flipped := bytes.Clone(data)
+	flipped[len(flipped)/2] ^= 0x01
+	nan := New(testHash, "Normal", config.Default())
+	nan.Config.SongRate = math.NaN()
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, ErrFormat},
+		{"bad magic", badMagic, ErrFormat},
+		{"bad version", badVersion, ErrVersion},
+		{"bad checksum", badCRC, ErrChecksum},
+		{"flipped bit", flipped, ErrChecksum},
+		{"truncated", data[:len(data)/2], ErrChecksum},
+		{"truncated body", withChecksum(body[:len(body)/2]), ErrCorrupt},
+		{"trailing bytes", withChecksum(append(bytes.Clone(body), 0)), ErrCorrupt},
+		{"huge event count", hugeEventCount(t), ErrCorrupt},
+		{"NaN song rate", marshal(t, nan), ErrCorrupt},
+		{"huge string length", withChecksum(binary.AppendUvarint(append([]byte(magic), append([]byte{version}, make([]byte, 32)...)...), 1<<62)), ErrCorrupt},
+	}
+	for _, tt := range tests {
+		if _, err := Unmarshal(tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// hugeEventCount membuat file valid tanpa event lalu mengganti jumlah event
+// dengan angka yang mustahil.
+func hugeEventCount(t testing.TB) []byte {
+	data := marshal(t, New(testHash, "Normal", config.Default()))
+	body := data[:len(data)-5] // Buang jumlah event (0) dan CRC.
+	return withChecksum(binary.AppendUvarint(body, 1<<62))
+}
+
+// hugeTickDelta membuat file dengan event yang selisih tick-nya sangat besar,
+// maju lalu mundur.
+func hugeTickDelta(t testing.TB) []byte {
+	data := marshal(t, New(testHash, "Normal", config.Default()))
+	body := binary.AppendUvarint(bytes.Clone(data[:len(data)-5]), 2)
+	body = append(body, byte(Press)<<4)
+	body = binary.AppendVarint(body, 1<<62)
+	body = append(body, byte(Seek)<<4)
+	body = binary.AppendVarint(body, -1<<62)
+	body = binary.AppendVarint(body, 1<<62)
+	return withChecksum(body)
+}
+
+// fuzzSeeds adalah input awal fuzz: rekaman nyata dan versi rusaknya.
+func fuzzSeeds(f *testing.F) [][]byte {
+	data := marshal(f, recording(f))
+	body := data[:len(data)-4]
+	return [][]byte{
+		data,
+		marshal(f, New(testHash, "Normal", config.Default())),
+		data[:len(data)/2],
+		withChecksum(body[:len(body)/2]),
+		append([]byte("XBRP"), data[4:]...),
+		append(bytes.Clone(body), 0, 0, 0, 0),
+		hugeEventCount(f),
+		hugeTickDelta(f),
+	}
+}
+
+// checkReread memastikan replay yang berhasil dibaca bisa ditulis ulang dan
+// dibaca lagi tanpa perubahan apa pun.
+func checkReread(t *testing.T, r *Replay) {
+	again, err := Unmarshal(marshal(t, r))
+	if err != nil {
+		t.Fatalf("re-read: %v", err)
+	}
+	if !reflect.DeepEqual(again, r) {
+		t.Fatalf("re-read = %+v, want %+v", again, r)
+	}
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	for _, seed := range fuzzSeeds(f) {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if r, err := Unmarshal(data); err == nil {
+			checkReread(t, r)
+		}
+	})
+}
+
+// FuzzUnmarshalBody memberi CRC yang benar pada setiap input, jadi yang diuji
+// adalah decoder badan file, bukan pemeriksaan checksum.
+func FuzzUnmarshalBody(f *testing.F) {
+	for _, seed := range fuzzSeeds(f) {
+		if len(seed) >= 4 {
+			f.Add(seed[:len(seed)-4])
+		}
+	}
+	f.Fuzz(func(t *testing.T, body []byte) {
+		r, err := Unmarshal(withChecksum(body))
+		if errors.Is(err, ErrChecksum) {
+			t.Fatalf("valid checksum rejected: %v", err)
+		}
+		if err == nil {
+			checkReread(t, r)
+		}
+	})
+}
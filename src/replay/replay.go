@@ -0,0 +1,154 @@
+// Package replay merekam input pemain selama lagu agar run bisa diputar
+// ulang lewat kode penilaian yang sama dan menghasilkan skor yang sama.
+package replay
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rizalmf/old-boys/src/config"
+	"github.com/rizalmf/old-boys/src/input"
+)
+
+const (
+	// LastName adalah nama replay run terakhir.
+	LastName = "last"
+	// Ext adalah ekstensi file replay.
+	Ext = ".obr"
+)
+
+type EventKind uint8
+
+const (
+	Press EventKind = iota
+	Release
+	Seek // Lagu dimundurkan setelah pause, dari Tick ke To.
+)
+
+// Event adalah satu input pada posisi lagu (tick chart). Tick sudah
+// dibulatkan dengan Quantize.
+type Event struct {
+	Kind EventKind
+	Lane uint8
+	Tick float64
+	To   float64
+}
+
+type Replay struct {
+	ChartHash  string
+	Difficulty string
+	Config     config.Config // Pengaturan dan modifier saat run direkam.
+	Score      int           // Skor hasil run, untuk dicocokkan saat diputar ulang.
+	Accuracy   float64
+	Date       time.Time
+	Events     []Event
+}
+
+func New(chartHash, difficulty string, cfg config.Config) *Replay {
+	return &Replay{
+		ChartHash:  chartHash,
+		Difficulty: difficulty,
+		Config:     cfg,
+		Date:       time.Now(),
+	}
+}
+
+func (r *Replay) Press(lane int, tick float64) {
+	r.Events = append(r.Events, Event{Kind: Press, Lane: uint8(lane), Tick: tick})
+}
+
+func (r *Replay) Release(lane int, tick float64) {
+	r.Events = append(r.Events, Event{Kind: Release, Lane: uint8(lane), Tick: tick})
+}
+
+func (r *Replay) Seek(from, to float64) {
+	r.Events = append(r.Events, Event{Kind: Seek, Tick: from, To: to})
+}
+
+// Script membuat skrip input dari tekanan dan lepasan mulai event from
+// sampai Seek berikutnya. Skrip mengurutkan event berdasarkan waktu, jadi
+// bagian setelah rewind butuh skrip sendiri. seek adalah indeks Seek itu,
+// atau len(r.Events) jika tidak ada lagi.
+func (r *Replay) Script(from int) (s *input.Script, seek int) {
+	var events []input.Event
+	for seek = from; seek < len(r.Events) && r.Events[seek].Kind != Seek; seek++ {
+		e := r.Events[seek]
+		events = append(events, input.Event{Action: input.Lane(int(e.Lane)), Pressed: e.Kind == Press, At: e.Tick})
+	}
+	return input.NewScript(events), seek
+}
+
+// BestName adalah nama replay skor terbaik untuk kunci skor dari scores.Key,
+// jadi setiap kombinasi modifier punya replay terbaik sendiri. Hash chart
+// dipendekkan dan karakter selain huruf, angka dan titik dibuang supaya aman
+// sebagai nama file.
+func BestName(scoreKey string) string {
+	parts := strings.Split(scoreKey, "/")
+	if len(parts[0]) > 16 {
+		parts[0] = parts[0][:16]
+	}
+	for i, p := range parts {
+		parts[i] = strings.Map(func(r rune) rune {
+			if r == '.' || '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' {
+				return r
+			}
+			return -1
+		}, p)
+	}
+	return "best-" + strings.Join(parts, "-")
+}
+
+// Dir mengembalikan folder replay di folder data user.
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "replays"), nil
+}
+
+// Save menulis replay dengan nama name ke folder replay.
+func (r *Replay) Save(name string) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name+Ext), data, 0o644)
+}
+
+// Load membaca replay dengan nama name dari folder replay.
+func Load(name string) (*Replay, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return Open(filepath.Join(dir, name+Ext))
+}
+
+// Open membaca file replay dari path mana pun.
+func Open(path string) (*Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Unmarshal(data)
+}
+
+// ReadFS membaca file replay dari fsys, mis. file yang di-drop ke jendela game.
+func ReadFS(fsys fs.FS, name string) (*Replay, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return Unmarshal(data)
+}
@@ -0,0 +1,47 @@
+package replay
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rizalmf/old-boys/src/config"
+	"github.com/rizalmf/old-boys/src/input"
+)
+
+func TestScriptStopsAtSeek(t *testing.T) {
+	r := New(testHash, "Normal", config.Default())
+	r.Press(0, 100)
+	r.Release(0, 110)
+	r.Press(2, 200)
+	r.Seek(250, 50)
+	r.Press(1, 60)
+	r.Seek(80, 70)
+
+	s, seek := r.Script(0)
+	if seek != 3 {
+		t.Fatalf("first seek at event %d, want 3", seek)
+	}
+	want := []input.Event{
+		{Action: input.Lane0, Pressed: true, At: 100},
+		{Action: input.Lane0, Pressed: false, At: 110},
+		{Action: input.Lane2, Pressed: true, At: 200},
+	}
+	if got := s.Poll(250, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("before the rewind: %v, want %v", got, want)
+	}
+
+	// Tekanan setelah rewind lebih awal dari tekanan sebelumnya, jadi ada di
+	// skrip sendiri.
+	s, seek = r.Script(seek + 1)
+	if seek != 5 {
+		t.Fatalf("second seek at event %d, want 5", seek)
+	}
+	want = []input.Event{{Action: input.Lane1, Pressed: true, At: 60}}
+	if got := s.Poll(80, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("after the rewind: %v, want %v", got, want)
+	}
+
+	if s, seek = r.Script(seek + 1); seek != len(r.Events) || !s.Done() {
+		t.Errorf("after the last seek: seek %d, done %v", seek, s.Done())
+	}
+}
@@ -0,0 +1,65 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/judge"
+	"github.com/rizalmf/old-boys/src/replay"
+)
+
+// attractIdleFrames adalah lama layar judul didiamkan sebelum demo diputar.
+const attractIdleFrames = 30 * constants.TPS
+
+// autoplayJitters adalah pilihan simpangan timing autoplay (ms).
+var autoplayJitters = []float64{0, 5, 10, 20, 35}
+
+// startAutoplay memutar chart dengan bot lewat replay viewer, sehingga band
+// dan highway bereaksi seperti saat dimainkan. attract = demo di layar judul
+// yang berhenti saat ada input.
+func (g *MainScene) startAutoplay(attract bool) {
+	rng := rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 0))
+	g.startReplay(replay.Autoplay(g.chart, g.config, g.config.AutoplayJitter, rng))
+	if g.replaying {
+		g.autoplay = true
+		g.attract = attract
+	}
+}
+
+// updateAttract memulai demo setelah layar judul didiamkan cukup lama.
+func (g *MainScene) updateAttract() bool {
+	if g.input.Any() {
+		g.menuIdle = 0
+		return false
+	}
+	g.menuIdle++
+	if g.menuIdle < attractIdleFrames {
+		return false
+	}
+	g.startAutoplay(true)
+	return true
+}
+
+// stepJitter memilih simpangan autoplay berikutnya dari autoplayJitters.
+func stepJitter(v float64, dir int) float64 {
+	i := max(slices.Index(autoplayJitters, v), 0)
+	return autoplayJitters[(i+len(autoplayJitters)+dir)%len(autoplayJitters)]
+}
+
+// drawAutoplayResult menampilkan berapa not yang kena Perfect oleh autoplay.
+// Tanpa jitter, not yang tidak Perfect menandakan chart yang tidak bisa
+// dimainkan (misalnya not bertumpuk di lajur yang sama).
+func (g *MainScene) drawAutoplayResult(screen *ebiten.Image) {
+	perfect, total := g.result.Counts[judge.Perfect], g.result.Counts.Total()
+	clr := color.Color(color.RGBA{40, 160, 40, 255})
+	if perfect < total {
+		clr = lateColor
+	}
+	g.drawText(screen, fmt.Sprintf("AUTOPLAY  %s  %d/%d", judge.Perfect, perfect, total), 14, 20, 307, text.AlignStart, clr)
+}
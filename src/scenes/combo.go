@@ -0,0 +1,43 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/rizalmf/old-boys/src/scoring"
+)
+
+var fullComboColor = color.RGBA{240, 190, 30, 255}
+
+// drawCombo menampilkan combo dan pengalinya di atas jalur not.
+func (g *MainScene) drawCombo(screen *ebiten.Image) {
+	if g.run.Combo < 2 {
+		return
+	}
+	cx := float64(firstNoteX + noteLineWidth*3/2)
+	g.drawText(screen, fmt.Sprintf("%d", g.run.Combo), 32, cx, NoteY+8, text.AlignCenter, color.White)
+	label := "COMBO"
+	if m, ok := g.run.Rule.(scoring.Multiplier); ok && m.Multiplier() > 1 {
+		label = fmt.Sprintf("COMBO  x%d", m.Multiplier())
+	}
+	g.drawText(screen, label, 12, cx, NoteY+44, text.AlignCenter, color.White)
+}
+
+// drawComboResult menampilkan max combo dan badge full combo di layar skor.
+func (g *MainScene) drawComboResult(screen *ebiten.Image) {
+	g.drawText(screen, fmt.Sprintf("%s  %d", g.lang.MaxCombo(), g.result.MaxCombo), 14, 20, 345, text.AlignStart, color.Black)
+	if g.run.GhostTapPenalty {
+		g.drawText(screen, fmt.Sprintf("%s  %d", g.lang.GhostTaps(), g.result.GhostTaps), 14, 20, 326, text.AlignStart, color.Black)
+	}
+
+	if !g.result.FullCombo {
+		return
+	}
+	x, y := float32(565), float32(316)
+	vector.DrawFilledRect(screen, x, y, 130, 26, fullComboColor, false)
+	vector.StrokeRect(screen, x, y, 130, 26, 2, color.White, false)
+	g.drawText(screen, g.lang.FullCombo(), 16, float64(x+65), float64(y+4), text.AlignCenter, color.White)
+}
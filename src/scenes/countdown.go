@@ -0,0 +1,49 @@
+package scenes
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	skipIntroBeats = 4 // Skip intro berhenti sekian ketukan sebelum not pertama.
+	countdownBeats = 3 // Hitung mundur 3-2-1 lalu "GO" tepat di not pertama.
+
+	// countdownBeat menggantikan satu ketukan jika chart tidak punya tempo.
+	countdownBeat = 500 * time.Millisecond
+)
+
+// countdownBeatTicks adalah panjang satu ketukan hitung mundur dan skip
+// intro: ketukan lagu jika chart punya tempo, atau countdownBeat waktu nyata
+// jika tidak, supaya tidak mengikuti tempo karangan.
+func (g *MainScene) countdownBeatTicks() float64 {
+	if g.chart.HasTempo() {
+		return g.chart.BeatTicks(g.chart.FirstTick())
+	}
+	return g.run.MsToTicks(float64(countdownBeat.Milliseconds()))
+}
+
+// drawCountdown menampilkan hitung mundur yang mengikuti ketukan lagu
+// menjelang not pertama.
+func (g *MainScene) drawCountdown(screen *ebiten.Image) {
+	first := g.chart.FirstTick()
+	beatsLeft := (first - g.currentTick) / g.countdownBeatTicks()
+	if beatsLeft <= -1 || beatsLeft > countdownBeats {
+		return
+	}
+
+	texts := "GO!"
+	if beatsLeft > 0 {
+		texts = strconv.Itoa(int(math.Ceil(beatsLeft)))
+	}
+
+	// Membesar di awal setiap ketukan.
+	pulse := beatsLeft - math.Floor(beatsLeft)
+	fontSize := 60 + 30*pulse
+	g.drawText(screen, texts, fontSize, float64(firstNoteX+noteLineWidth*3/2), NoteY-fontSize-10, text.AlignCenter, color.Black)
+}
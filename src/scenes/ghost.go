@@ -0,0 +1,85 @@
+package scenes
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/engine"
+	"github.com/rizalmf/old-boys/src/replay"
+)
+
+// ghostRun memutar replay skor terbaik di samping permainan dengan engine
+// penilaiannya sendiri.
+type ghostRun struct {
+	run    *engine.Run
+	inputs []engine.Input
+	index  int // Input berikutnya.
+}
+
+// startGhost memuat replay skor terbaik chart ini dengan modifier run yang
+// sama jika opsi ghost aktif. Ghost memakai pengaturan skor pemain supaya
+// skornya bisa dibandingkan, dan modifier lain dari replay-nya.
+func (g *MainScene) startGhost() {
+	g.ghost = nil
+	if g.replaying || !g.config.Ghost {
+		return
+	}
+	r, err := replay.Load(replay.BestName(g.scoreKey(g.runModifiers())))
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		log.Printf("ghost: load personal best replay: %v", err)
+		return
+	}
+	if r.ChartHash != g.chart.Hash {
+		return
+	}
+
+	cfg := r.Config
+	cfg.Scoring = g.config.Scoring
+	cfg.ComboMultiplier = g.config.ComboMultiplier
+	cfg.Grades = g.config.Grades
+	g.ghost = &ghostRun{
+		run:    engine.New(g.chart, cfg),
+		inputs: engine.ReplayInputs(r),
+	}
+}
+
+// updateGhost menjalankan input ghost sampai tick.
+func (g *MainScene) updateGhost(tick float64) {
+	ghost := g.ghost
+	if ghost == nil {
+		return
+	}
+	n := ghost.index
+	for n < len(ghost.inputs) && ghost.inputs[n].Tick <= tick {
+		n++
+	}
+	ghost.run.Play(ghost.inputs[ghost.index:n])
+	ghost.index = n
+	ghost.run.Advance(tick, 0, nil)
+}
+
+// drawGhost menampilkan skor pemain, skor ghost dan selisihnya.
+func (g *MainScene) drawGhost(screen *ebiten.Image) {
+	if g.ghost == nil {
+		return
+	}
+	you, ghost := g.run.Rule.Score(), g.ghost.run.Rule.Score()
+	x := float64(constants.ScreenWidth - 15)
+	g.drawText(screen, fmt.Sprintf("YOU  %d", you), 16, x, 10, text.AlignEnd, color.Black)
+	g.drawText(screen, fmt.Sprintf("GHOST  %d", ghost), 16, x, 30, text.AlignEnd, color.RGBA{90, 90, 90, 255})
+
+	delta, clr := fmt.Sprintf("%+d", you-ghost), color.Color(color.RGBA{40, 160, 40, 255})
+	if you < ghost {
+		clr = lateColor
+	}
+	g.drawText(screen, delta, 22, x, 50, text.AlignEnd, clr)
+}
@@ -0,0 +1,16 @@
+package scenes
+
+import "github.com/rizalmf/old-boys/src/engine"
+
+// showGhostTap menandai ghost tap: combo putus, health berkurang dan tercatat
+// di layar hasil.
+func (g *MainScene) showGhostTap(hit engine.Hit) {
+	_, char := g.laneParts(hit.Lane)
+	char.MarkImage = g.markMissImage
+	char.MarkText, char.MarkTextColor = "GHOST", lateColor
+	char.IsMark = true
+	char.CurrentMarkTime = 0
+	if hit.Failed {
+		g.fail()
+	}
+}
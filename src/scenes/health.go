@@ -0,0 +1,65 @@
+package scenes
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/entities"
+)
+
+const (
+	failFadeFrames = 2 * constants.TPS // Lama stem memudar setelah gagal.
+
+	healthBarX     = firstNoteX - 16
+	healthBarWidth = 8
+)
+
+// fail menghentikan permainan: semua anggota band kena MISS lalu stem
+// memudar sebelum layar hasil muncul.
+func (g *MainScene) fail() {
+	g.failFade = failFadeFrames
+	for _, char := range []*entities.Char{&g.Man1, &g.Man2, &g.Man3} {
+		char.MarkImage = g.markMissImage
+		char.MarkText = ""
+		char.IsMark = true
+		char.CurrentMarkTime = 0
+	}
+}
+
+// updateFail memudarkan stem dan metronome, lalu pindah ke layar hasil.
+func (g *MainScene) updateFail() {
+	g.failFade--
+	v := float64(g.failFade) / failFadeFrames
+	for _, p := range g.songPlayers() {
+		p.SetVolume(min(p.Volume(), v))
+	}
+	if g.failFade > 0 {
+		return
+	}
+	g.stopSong()
+	g.finishRun()
+	g.state = inGameFinish
+}
+
+// drawHealth menggambar bar health vertikal di samping jalur not, dan layar
+// FAILED yang menggelap selama stem memudar.
+func (g *MainScene) drawHealth(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, healthBarX, NoteY, healthBarWidth, NoteHeight, color.RGBA{24, 24, 24, 160}, false)
+
+	h := float32(g.run.Health * NoteHeight)
+	clr := color.RGBA{40, 180, 60, 255}
+	if g.run.Health < 0.25 {
+		clr = color.RGBA{220, 50, 40, 255}
+	}
+	vector.DrawFilledRect(screen, healthBarX, NoteY+NoteHeight-h, healthBarWidth, h, clr, false)
+	vector.StrokeRect(screen, healthBarX, NoteY, healthBarWidth, NoteHeight, 1, color.Black, false)
+
+	if g.run.Failed {
+		a := uint8(160 * (1 - float64(g.failFade)/failFadeFrames))
+		vector.DrawFilledRect(screen, 0, 0, constants.ScreenWidth, constants.ScreenHeight, color.RGBA{0, 0, 0, a}, false)
+		g.drawText(screen, g.lang.Failed(), 56, constants.ScreenWidth/2, constants.ScreenHeight/2-40, text.AlignCenter, lateColor)
+	}
+}
@@ -0,0 +1,97 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/engine"
+	"github.com/rizalmf/old-boys/src/scores"
+	"github.com/rizalmf/old-boys/src/scoring"
+)
+
+func (g *MainScene) scoreKey(m scores.Modifiers) string {
+	return scores.Key(g.chart.Hash, g.chart.Difficulty, m)
+}
+
+// runModifiers adalah modifier run yang sedang atau baru selesai dimainkan.
+func (g *MainScene) runModifiers() scores.Modifiers {
+	return scores.Modifiers{
+		Rule:     g.run.Rule.Name(),
+		Preset:   g.run.Preset.String(),
+		Rate:     g.run.Rate,
+		NoFail:   g.run.NoFail,
+		GhostTap: g.run.GhostTapPenalty,
+	}
+}
+
+// menuModifiers adalah modifier run berikutnya menurut pengaturan pemain.
+func (g *MainScene) menuModifiers() scores.Modifiers {
+	return scores.Modifiers{
+		Rule:     scoring.New(engine.RuleName(g.chart, g.config), scoring.Options{}).Name(),
+		Preset:   g.config.Judgement.String(),
+		Rate:     engine.SongRate(g.config.SongRate),
+		NoFail:   g.config.NoFail,
+		GhostTap: g.config.GhostTapPenalty,
+	}
+}
+
+// record merangkum run yang baru selesai untuk disimpan.
+func (g *MainScene) record() scores.Record {
+	return scores.Record{
+		Score:     g.result.Score,
+		Accuracy:  g.result.Accuracy,
+		Grade:     g.result.Grade.String(),
+		Counts:    g.result.Counts,
+		MaxCombo:  g.result.MaxCombo,
+		Modifiers: g.runModifiers(),
+		Date:      time.Now(),
+	}
+}
+
+// submitScore membandingkan run dengan skor terbaik lalu menyimpannya.
+// Run yang gagal tidak dicatat.
+func (g *MainScene) submitScore() {
+	key := g.scoreKey(g.runModifiers())
+	g.prevBest, g.hasPrevBest = g.scores.Get(key)
+	g.newBest = false
+	if g.result.Failed {
+		return
+	}
+	g.newBest = g.scores.Submit(key, g.record())
+	if !g.newBest {
+		return
+	}
+	if err := g.scores.Save(); err != nil {
+		log.Printf("scores: save: %v", err)
+	}
+}
+
+func formatBest(r scores.Record) string {
+	return fmt.Sprintf("%d  %s  %.2f%%", r.Score, r.Grade, r.Accuracy)
+}
+
+// drawMenuBest menampilkan skor terbaik chart ini dengan modifier dari
+// pengaturan di layar judul.
+func (g *MainScene) drawMenuBest(screen *ebiten.Image) {
+	best, ok := g.scores.Get(g.scoreKey(g.menuModifiers()))
+	if !ok {
+		return
+	}
+	texts := fmt.Sprintf("%s  BEST  %s", g.chart.Difficulty, formatBest(best))
+	g.drawText(screen, texts, 18, 15, constants.ScreenHeight-30, text.AlignStart, color.Black)
+}
+
+// drawResultBest menampilkan apakah run ini memecahkan skor terbaik.
+func (g *MainScene) drawResultBest(screen *ebiten.Image) {
+	switch {
+	case g.newBest:
+		g.drawText(screen, "NEW BEST!", 22, 630, 94, text.AlignCenter, fullComboColor)
+	case g.hasPrevBest:
+		g.drawText(screen, "BEST  "+formatBest(g.prevBest), 12, 630, 98, text.AlignCenter, color.Black)
+	}
+}
@@ -0,0 +1,119 @@
+package scenes
+
+import (
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/input"
+	"github.com/rizalmf/old-boys/src/input/device"
+)
+
+const padNoticeFrames = 2 * constants.TPS
+
+// menuKeys adalah aksi menu keyboard. Tombol lajur dari config ditambahkan
+// di atasnya oleh applyKeyBindings.
+var menuKeys = map[ebiten.Key][]input.Action{
+	ebiten.KeyUp:        {input.Up},
+	ebiten.KeyDown:      {input.Down},
+	ebiten.KeyLeft:      {input.Left},
+	ebiten.KeyRight:     {input.Right},
+	ebiten.KeyEnter:     {input.Confirm},
+	ebiten.KeySpace:     {input.Confirm},
+	ebiten.KeyEscape:    {input.Back, input.Pause},
+	ebiten.KeyTab:       {input.Settings},
+	ebiten.KeyBackspace: {input.Clear},
+	ebiten.KeyDelete:    {input.Clear},
+	ebiten.KeyR:         {input.Replay},
+	ebiten.KeyA:         {input.Autoplay},
+	ebiten.Key1:         {input.HalfSpeed},
+	ebiten.Key2:         {input.NormalSpeed},
+	ebiten.Key3:         {input.DoubleSpeed},
+}
+
+// menuButtons adalah aksi menu gamepad: D-pad untuk navigasi, A/B untuk
+// pilih/kembali, Start untuk pause dan Back untuk pengaturan.
+var menuButtons = map[ebiten.StandardGamepadButton][]input.Action{
+	ebiten.StandardGamepadButtonLeftTop:     {input.Up},
+	ebiten.StandardGamepadButtonLeftBottom:  {input.Down},
+	ebiten.StandardGamepadButtonLeftLeft:    {input.Left},
+	ebiten.StandardGamepadButtonLeftRight:   {input.Right},
+	ebiten.StandardGamepadButtonRightBottom: {input.Confirm},
+	ebiten.StandardGamepadButtonRightRight:  {input.Back},
+	ebiten.StandardGamepadButtonRightLeft:   {input.Clear},
+	ebiten.StandardGamepadButtonCenterRight: {input.Pause},
+	ebiten.StandardGamepadButtonCenterLeft:  {input.Settings},
+}
+
+// reservedKey cek apakah tombol punya aksi menu selain navigasi. Tombol
+// seperti itu tidak boleh jadi tombol lajur supaya memukul not tidak ikut
+// memilih, membatalkan atau membuka menu. Panah dan D-pad boleh dipakai.
+func reservedKey[K comparable](menu map[K][]input.Action, k K) bool {
+	for _, a := range menu[k] {
+		if a < input.Up || a > input.Right {
+			return true
+		}
+	}
+	return false
+}
+
+// newInput menyiapkan sumber input perangkat. Peta tombol dan zona sentuh
+// diisi setelah lajur dimuat. Replay punya Manager sendiri supaya tombol
+// lajur pemain tidak ikut menilai not saat replay diputar.
+func (g *MainScene) newInput() {
+	g.keyboard = device.NewKeyboard()
+	g.gamepad = device.NewGamepad()
+	g.mouse = device.NewMouse()
+	g.touch = device.NewTouch()
+	g.input = input.NewManager(g.keyboard, g.gamepad, g.mouse, g.touch)
+	g.replayInput = input.NewManager()
+}
+
+// bindActions menggabungkan aksi menu dengan tombol setiap lajur.
+func bindActions[K comparable](menu map[K][]input.Action, lanes [][]K) map[K][]input.Action {
+	bindings := make(map[K][]input.Action, len(menu))
+	for k, actions := range menu {
+		bindings[k] = slices.Clone(actions)
+	}
+	for i, keys := range lanes {
+		for _, k := range keys {
+			bindings[k] = append(bindings[k], input.Lane(i))
+		}
+	}
+	return bindings
+}
+
+// resetPointers melupakan semua jari dan klik mouse yang sedang ditahan.
+func (g *MainScene) resetPointers() {
+	g.mouse.Reset()
+	g.touch.Reset()
+}
+
+// pollInput membaca semua sumber input pada tick. Lagu otomatis di-pause
+// jika gamepad terputus saat bermain.
+func (g *MainScene) pollInput(tick float64) {
+	g.input.Update(tick)
+	if g.padNotice > 0 {
+		g.padNotice--
+	}
+	if g.gamepad.JustConnected() {
+		g.padNoticeText, g.padNotice = g.lang.ControllerConnected(), padNoticeFrames
+	}
+	if !g.gamepad.JustDisconnected() {
+		return
+	}
+	g.padNoticeText, g.padNotice = g.lang.ControllerDisconnected(), padNoticeFrames
+	if g.state == inGamePlay && !g.run.Failed {
+		g.pause()
+	}
+}
+
+// drawPadNotice menampilkan pemberitahuan gamepad tersambung/terputus.
+func (g *MainScene) drawPadNotice(screen *ebiten.Image) {
+	if g.padNotice == 0 {
+		return
+	}
+	g.drawText(screen, g.padNoticeText, 16, constants.ScreenWidth/2, 6, text.AlignCenter, color.Black)
+}
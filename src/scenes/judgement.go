@@ -0,0 +1,181 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/rizalmf/old-boys/src/chart"
+	"github.com/rizalmf/old-boys/src/engine"
+	"github.com/rizalmf/old-boys/src/entities"
+	"github.com/rizalmf/old-boys/src/judge"
+)
+
+const (
+	finishRowY   = 140
+	finishRowGap = 38
+
+	histogramX    = 20
+	histogramY    = 8
+	histogramW    = 360
+	histogramH    = 40
+	histogramBins = 41
+)
+
+var (
+	earlyColor = color.RGBA{30, 90, 220, 255}
+	lateColor  = color.RGBA{220, 50, 40, 255}
+)
+
+// finishRun menghitung akurasi dan grade saat lagu selesai.
+func (g *MainScene) finishRun() {
+	g.result = g.run.Result()
+	if g.replaying {
+		return
+	}
+	g.submitScore()
+	g.saveRecording()
+}
+
+// laneParts mengembalikan stem dan karakter milik sebuah lajur.
+func (g *MainScene) laneParts(lane chart.LaneId) (*audio.Player, *entities.Char) {
+	switch lane {
+	case chart.DrumsLaneId:
+		return g.DrumsAudio, &g.Man3
+	case chart.BassLaneId:
+		return g.BassAudio, &g.Man2
+	}
+	return g.GuitarAudio, &g.Man1
+}
+
+func (g *MainScene) markImage(j judge.Judgement) *ebiten.Image {
+	switch j {
+	case judge.Perfect:
+		return g.markPerfectImage
+	case judge.Great:
+		return g.markGreatImage
+	case judge.Good:
+		return g.markGoodImage
+	case judge.Bad:
+		return g.markBadImage
+	}
+	return g.markMissImage
+}
+
+// showHit menampilkan hasil penilaian engine pada stem dan karakter lajurnya.
+func (g *MainScene) showHit(hit engine.Hit) {
+	if hit.Note == nil {
+		g.showGhostTap(hit)
+		return
+	}
+	j := hit.Judgement
+
+	player, char := g.laneParts(hit.Lane)
+	char.MarkText = ""
+	if j != judge.Miss && j != judge.Perfect {
+		char.MarkText, char.MarkTextColor = "LATE", lateColor
+		if hit.OffsetMs < 0 {
+			char.MarkText, char.MarkTextColor = "EARLY", earlyColor
+		}
+	}
+
+	if j == judge.Miss {
+		player.SetVolume(0)
+	} else {
+		player.SetVolume(1)
+	}
+
+	char.MarkImage = g.markImage(j)
+	char.IsMark = true
+	char.CurrentMarkTime = 0
+	if hit.Failed {
+		g.fail()
+	}
+}
+
+// newMarkImage membuat tanda penilaian sederhana seukuran gambar mark bawaan.
+func (g *MainScene) newMarkImage(label string, bg color.Color) *ebiten.Image {
+	w, h := g.markGoodImage.Bounds().Dx(), g.markGoodImage.Bounds().Dy()
+	img := ebiten.NewImage(w, h)
+	vector.DrawFilledRect(img, 4, 8, float32(w-8), float32(h-16), bg, true)
+	vector.StrokeRect(img, 4, 8, float32(w-8), float32(h-16), 2, color.White, true)
+
+	opt := &text.DrawOptions{}
+	opt.GeoM.Translate(float64(w)/2, float64(h)/2)
+	opt.ColorScale.ScaleWithColor(color.White)
+	opt.PrimaryAlign = text.AlignCenter
+	opt.SecondaryAlign = text.AlignCenter
+	text.Draw(img, label, &text.GoTextFace{
+		Source: g.fontSource,
+		Size:   16,
+	}, opt)
+	return img
+}
+
+// drawMarkText menampilkan EARLY / LATE di samping mark karakter.
+func (g *MainScene) drawMarkText(screen *ebiten.Image, char *entities.Char, x, y float64) {
+	if char.MarkText == "" {
+		return
+	}
+	g.drawText(screen, char.MarkText, 14, x+float64(char.MarkImage.Bounds().Dx())/2, y-14, text.AlignCenter, char.MarkTextColor)
+}
+
+// drawTimingHistogram menggambar sebaran selisih waktu tekan di layar skor,
+// beserta rata-rata dan simpangan bakunya.
+func (g *MainScene) drawTimingHistogram(screen *ebiten.Image) {
+	limit := g.run.Preset.Windows().Bad
+	bins := judge.Histogram(g.run.Offsets, histogramBins, limit)
+	peak := 1
+	for _, n := range bins {
+		peak = max(peak, n)
+	}
+
+	vector.DrawFilledRect(screen, histogramX, histogramY, histogramW, histogramH, color.RGBA{255, 255, 255, 120}, false)
+	barW := float32(histogramW) / histogramBins
+	for i, n := range bins {
+		h := float32(n) / float32(peak) * histogramH
+		clr := earlyColor
+		if i >= histogramBins/2 {
+			clr = lateColor
+		}
+		if i == histogramBins/2 {
+			clr = color.RGBA{40, 160, 40, 255}
+		}
+		vector.DrawFilledRect(screen, histogramX+barW*float32(i), histogramY+histogramH-h, barW-1, h, clr, false)
+	}
+	center := float32(histogramX + histogramW/2)
+	vector.StrokeLine(screen, center, histogramY, center, histogramY+histogramH, 1, color.Black, false)
+
+	mean, stddev := judge.Stats(g.run.Offsets)
+	g.drawText(screen, fmt.Sprintf("EARLY  -%.0fms", limit), 12, histogramX, histogramY+histogramH+2, text.AlignStart, earlyColor)
+	g.drawText(screen, fmt.Sprintf("+%.0fms  LATE", limit), 12, histogramX+histogramW, histogramY+histogramH+2, text.AlignEnd, lateColor)
+	g.drawText(screen, fmt.Sprintf("mean %+.1fms\nσ %.1fms", mean, stddev), 16, histogramX+histogramW+15, histogramY+4, text.AlignStart, color.Black)
+}
+
+// drawGrade menampilkan huruf grade dan akurasi di pojok kanan atas layar skor.
+func (g *MainScene) drawGrade(screen *ebiten.Image) {
+	if g.result.Failed {
+		g.drawText(screen, g.lang.Failed(), 40, 630, 20, text.AlignCenter, lateColor)
+		g.drawText(screen, fmt.Sprintf("%.2f%%", g.result.Accuracy), 18, 630, 72, text.AlignCenter, color.Black)
+		return
+	}
+	g.drawText(screen, g.result.Grade.String(), 64, 630, 0, text.AlignCenter, gradeColor(g.result.Grade))
+	g.drawText(screen, fmt.Sprintf("%.2f%%", g.result.Accuracy), 18, 630, 72, text.AlignCenter, color.Black)
+}
+
+func gradeColor(grade judge.Grade) color.Color {
+	switch grade {
+	case judge.GradeS:
+		return fullComboColor
+	case judge.GradeA:
+		return color.RGBA{40, 160, 40, 255}
+	case judge.GradeB:
+		return earlyColor
+	case judge.GradeC:
+		return color.RGBA{120, 80, 40, 255}
+	}
+	return lateColor
+}
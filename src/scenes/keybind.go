@@ -0,0 +1,303 @@
+package scenes
+
+import (
+	"image"
+	"image/color"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/rizalmf/old-boys/src/config"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/input"
+	"github.com/rizalmf/old-boys/src/input/device"
+)
+
+const (
+	maxLaneKeys    = 2 // Slot keyboard per lajur.
+	maxLaneButtons = 2 // Slot gamepad per lajur.
+	keysSlotCount  = maxLaneKeys + maxLaneButtons
+
+	keysRowY   = 120
+	keysRowGap = 45
+	keysSlotX  = 210
+	keysSlotW  = 120
+)
+
+// applyKeyBindings memasang tombol keyboard dan gamepad dari config ke setiap
+// lajur. Lajur tanpa tombol yang valid kembali ke tombol bawaan.
+func (g *MainScene) applyKeyBindings() {
+	fillLanes(&g.config.Keys, config.DefaultKeys(), len(g.lanes), func(names []string) bool {
+		return len(parseKeys(names)) > 0
+	})
+	fillLanes(&g.config.Buttons, config.DefaultButtons(), len(g.lanes), func(names []string) bool {
+		return len(device.ParseButtons(names)) > 0
+	})
+	keys := make([][]ebiten.Key, len(g.lanes))
+	buttons := make([][]ebiten.StandardGamepadButton, len(g.lanes))
+	for i := range g.lanes {
+		keys[i] = parseKeys(g.config.Keys[i])
+		buttons[i] = device.ParseButtons(g.config.Buttons[i])
+	}
+	g.keyboard.SetBindings(bindActions(menuKeys, keys))
+	g.gamepad.SetBindings(bindActions(menuButtons, buttons))
+}
+
+// fillLanes memastikan table punya n lajur yang valid, memakai defaults
+// untuk lajur yang kurang atau tidak valid.
+func fillLanes(table *[][]string, defaults [][]string, n int, valid func([]string) bool) {
+	for len(*table) < n {
+		*table = append(*table, defaults[len(*table)])
+	}
+	for i := range n {
+		if !valid((*table)[i]) {
+			(*table)[i] = defaults[i]
+		}
+	}
+}
+
+func parseKeys(names []string) []ebiten.Key {
+	var keys []ebiten.Key
+	for _, name := range names {
+		var k ebiten.Key
+		if err := k.UnmarshalText([]byte(name)); err != nil {
+			log.Printf("keys: ignoring key %q: %v", name, err)
+			continue
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// keyLabel mempersingkat nama tombol untuk ditampilkan ("ArrowLeft" → "Left").
+func keyLabel(name string) string {
+	if name == "" {
+		return "---"
+	}
+	return strings.TrimPrefix(name, "Arrow")
+}
+
+// slotTable mengembalikan tabel config milik slot (keyboard atau gamepad)
+// dan posisi slot di tabel itu.
+func (g *MainScene) slotTable(slot int) (*[][]string, int) {
+	if slot < maxLaneKeys {
+		return &g.config.Keys, slot
+	}
+	return &g.config.Buttons, slot - maxLaneKeys
+}
+
+// keyName mengembalikan nama tombol di slot, atau "" jika kosong.
+func (g *MainScene) keyName(lane, slot int) string {
+	table, i := g.slotTable(slot)
+	if i < len((*table)[lane]) {
+		return (*table)[lane][i]
+	}
+	return ""
+}
+
+// setKey mengisi slot dengan name, atau mengosongkannya jika name kosong.
+func (g *MainScene) setKey(lane, slot int, name string) {
+	table, i := g.slotTable(slot)
+	names := (*table)[lane]
+	switch {
+	case name == "":
+		if i < len(names) {
+			names = slices.Delete(names, i, i+1)
+		}
+	case i < len(names):
+		names[i] = name
+	default:
+		names = append(names, name)
+	}
+	(*table)[lane] = names
+}
+
+// bindKey memasang tombol ke slot. Tombol yang sudah dipakai slot lain
+// ditukar dengan tombol lama slot ini, asal lajur lain tidak jadi tanpa tombol.
+func (g *MainScene) bindKey(lane, slot int, name string) {
+	table, i := g.slotTable(slot)
+	base := slot - i
+	old := g.keyName(lane, slot)
+	g.keysMessage = ""
+	for l, names := range *table {
+		s := slices.Index(names, name)
+		if s < 0 || (l == lane && s == i) {
+			continue
+		}
+		if l == lane && old == "" {
+			return // Sudah terpasang di lajur ini.
+		}
+		if old == "" && len(names) == 1 {
+			g.keysMessage = g.lang.OnlyKey(keyLabel(name), g.lang.LaneName(l))
+			return
+		}
+		g.setKey(l, base+s, old)
+		g.keysMessage = g.lang.KeySwapped(keyLabel(name), g.lang.LaneName(l))
+	}
+	g.setKey(lane, slot, name)
+	g.applyKeyBindings()
+}
+
+// clearKey mengosongkan slot. Setiap lajur harus punya minimal satu tombol
+// keyboard dan satu tombol gamepad.
+func (g *MainScene) clearKey(lane, slot int) {
+	if g.keyName(lane, slot) == "" {
+		return
+	}
+	if table, _ := g.slotTable(slot); len((*table)[lane]) == 1 {
+		g.keysMessage = g.lang.NeedsKey(g.lang.LaneName(lane))
+		return
+	}
+	g.setKey(lane, slot, "")
+	g.keysMessage = ""
+	g.applyKeyBindings()
+}
+
+func (g *MainScene) openKeyBindings() {
+	g.state = inGameKeys
+	g.keysLane, g.keysSlot = 0, 0
+	g.keysCapture = false
+	g.keysMessage = ""
+}
+
+func keySlotRect(lane, slot int) image.Rectangle {
+	x, y := keysSlotX+keysSlotW*slot, keysRowY+keysRowGap*lane
+	return image.Rect(x, y, x+keysSlotW, y+keysRowGap)
+}
+
+// captureKey menunggu tombol baru untuk slot terpilih: tombol keyboard untuk
+// slot keyboard, tombol gamepad untuk slot gamepad. Aksi Back membatalkan,
+// dan tombol menu selain navigasi ditolak. Tombol yang dipasang dibaca langsung dari perangkat karena tombol apa pun
+// boleh dipilih, termasuk yang belum punya aksi.
+func (g *MainScene) captureKey() {
+	if g.input.JustPressed(input.Back) {
+		g.keysCapture = false
+		return
+	}
+	if g.keysSlot < maxLaneKeys {
+		if keys := inpututil.AppendJustPressedKeys(nil); len(keys) > 0 {
+			g.keysCapture = false
+			if reservedKey(menuKeys, keys[0]) {
+				g.keysMessage = g.lang.KeyReserved(keyLabel(keys[0].String()))
+				return
+			}
+			g.bindKey(g.keysLane, g.keysSlot, keys[0].String())
+		}
+		return
+	}
+	buttons := g.gamepad.JustPressedButtons()
+	if len(buttons) == 0 {
+		return
+	}
+	g.keysCapture = false
+	if reservedKey(menuButtons, buttons[0]) {
+		g.keysMessage = g.lang.KeyReserved(device.ButtonNames[buttons[0]])
+		return
+	}
+	g.bindKey(g.keysLane, g.keysSlot, device.ButtonNames[buttons[0]])
+}
+
+// UpdateInGameKeys menangani layar tombol lajur: panah untuk memilih slot,
+// Enter untuk mengganti, Backspace untuk mengosongkan, Esc kembali.
+func (g *MainScene) UpdateInGameKeys() {
+	if g.keysCapture {
+		g.captureKey()
+		return
+	}
+
+	rows := len(g.lanes) + 1 // + Back
+	if g.input.JustPressed(input.Back) {
+		g.state = inGameSettings
+		return
+	}
+	if g.input.JustPressed(input.Up) {
+		g.keysLane = (g.keysLane + rows - 1) % rows
+	}
+	if g.input.JustPressed(input.Down) {
+		g.keysLane = (g.keysLane + 1) % rows
+	}
+	if g.input.JustPressed(input.Left) {
+		g.keysSlot = (g.keysSlot + keysSlotCount - 1) % keysSlotCount
+	}
+	if g.input.JustPressed(input.Right) {
+		g.keysSlot = (g.keysSlot + 1) % keysSlotCount
+	}
+	if g.input.JustPressed(input.Clear) && g.keysLane < len(g.lanes) {
+		g.clearKey(g.keysLane, g.keysSlot)
+	}
+
+	selected := g.input.JustPressed(input.Confirm)
+	for _, pt := range g.input.Taps() {
+		for lane := range g.lanes {
+			for slot := range keysSlotCount {
+				if pt.In(keySlotRect(lane, slot)) {
+					g.keysLane, g.keysSlot = lane, slot
+					selected = true
+				}
+			}
+		}
+		if pt.In(keySlotRect(len(g.lanes), 0).Union(keySlotRect(len(g.lanes), keysSlotCount-1))) {
+			g.keysLane = len(g.lanes)
+			selected = true
+		}
+	}
+
+	if !selected {
+		return
+	}
+	if g.keysLane == len(g.lanes) {
+		g.state = inGameSettings
+		return
+	}
+	g.keysCapture = true
+	g.keysMessage = ""
+}
+
+func (g *MainScene) DrawInGameKeys(screen *ebiten.Image) {
+	g.DrawInGameMenu(screen)
+
+	vector.DrawFilledRect(screen, 0, 0, constants.ScreenWidth, constants.ScreenHeight, color.RGBA{255, 255, 255, 200}, false)
+
+	cx := float64(constants.ScreenWidth / 2)
+	g.drawText(screen, g.lang.KeyBindings(), 48, cx, 30, text.AlignCenter, color.Black)
+	g.drawText(screen, g.lang.Keyboard(), 16, keysSlotX+keysSlotW*maxLaneKeys/2, keysRowY-24, text.AlignCenter, color.Black)
+	g.drawText(screen, g.lang.Gamepad(g.gamepad.Count()), 16, keysSlotX+keysSlotW*(maxLaneKeys+keysSlotCount)/2, keysRowY-24, text.AlignCenter, color.Black)
+
+	for lane := range g.lanes {
+		y := float64(keysRowY + keysRowGap*lane)
+		vector.DrawFilledRect(screen, 20, float32(y)+6, 14, 14, g.lanes[lane].Color, false)
+		vector.StrokeRect(screen, 20, float32(y)+6, 14, 14, 1, color.Black, false)
+		g.drawText(screen, g.lang.LaneName(lane), 24, 45, y, text.AlignStart, color.Black)
+
+		for slot := range keysSlotCount {
+			r := keySlotRect(lane, slot)
+			label := keyLabel(g.keyName(lane, slot))
+			if lane == g.keysLane && slot == g.keysSlot {
+				label = "> " + label + " <"
+				if g.keysCapture {
+					label = "> ? <"
+				}
+			}
+			g.drawText(screen, label, 22, float64(r.Min.X+keysSlotW/2), y, text.AlignCenter, color.Black)
+		}
+	}
+
+	back := g.lang.Back()
+	if g.keysLane == len(g.lanes) {
+		back = "> " + back + " <"
+	}
+	g.drawText(screen, back, 24, cx, float64(keysRowY+keysRowGap*len(g.lanes)), text.AlignCenter, color.Black)
+
+	if g.keysMessage != "" {
+		g.drawText(screen, g.keysMessage, 16, cx, 310, text.AlignCenter, lateColor)
+	}
+	hint := g.lang.KeysHint()
+	if g.keysCapture {
+		hint = g.lang.PressKey()
+	}
+	g.drawText(screen, hint, 16, cx, 345, text.AlignCenter, color.Black)
+}
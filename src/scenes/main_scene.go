@@ -2,19 +2,16 @@ package scenes
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"image"
 	"image/color"
 	_ "image/png"
 	"io"
 	"log"
-	"math"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/audio"
-	"github.com/hajimehoshi/ebiten/v2/audio/mp3"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
@@ -23,8 +20,18 @@ import (
 	"github.com/rizalmf/old-boys/assets/notes"
 	"github.com/rizalmf/old-boys/assets/sounds"
 	"github.com/rizalmf/old-boys/src/animations"
+	"github.com/rizalmf/old-boys/src/chart"
+	"github.com/rizalmf/old-boys/src/config"
 	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/engine"
 	"github.com/rizalmf/old-boys/src/entities"
+	"github.com/rizalmf/old-boys/src/input"
+	"github.com/rizalmf/old-boys/src/input/device"
+	"github.com/rizalmf/old-boys/src/judge"
+	"github.com/rizalmf/old-boys/src/lang"
+	"github.com/rizalmf/old-boys/src/replay"
+	"github.com/rizalmf/old-boys/src/scores"
+	"github.com/rizalmf/old-boys/src/sound"
 )
 
 type inGameState int8
@@ -34,30 +41,21 @@ const (
 	inGamePlay
 	inGameFinish
 	inGameLoading
+	inGamePause
+	inGameSettings
+	inGameReplay
+	inGameKeys
 )
 
 const (
+	finishDelayTicks = 300 // Jeda setelah not terakhir sebelum layar skor.
+
 	noteLineWidth = 40
 	firstNoteX    = 505
 	NoteY         = 200
 	NoteHeight    = 145
 )
 
-type ScoreCriteria struct {
-	perfect int
-	good    int
-	miss    int
-}
-
-type Score struct {
-	perfectNote uint
-	goodNote    uint
-	missNote    int
-	guitarScore ScoreCriteria
-	bassScore   ScoreCriteria
-	drumScore   ScoreCriteria
-}
-
 type MainScene struct {
 	isLoaded     bool
 	loadCount    int
@@ -71,6 +69,12 @@ type MainScene struct {
 	// State
 	state       inGameState
 	isVeryBegin bool
+	lang        lang.Lang
+	config      config.Config
+	scores      *scores.Store
+	prevBest    scores.Record // Skor terbaik sebelum run terakhir.
+	hasPrevBest bool
+	newBest     bool // Run terakhir memecahkan skor terbaik.
 
 	// Images
 	Man1         entities.Char
@@ -91,52 +95,99 @@ type MainScene struct {
 	finishAnimY      float64
 	finishAnimActive bool
 	isFinishAnim     bool
+	// Settings
+	settingsIndex int // Baris pengaturan yang aktif.
+	keysLane      int // Lajur (atau Back) yang dipilih di layar tombol.
+	keysSlot      int
+	keysCapture   bool // Menunggu tombol baru untuk slot terpilih.
+	keysMessage   string
+	// Gamepad
+	padNotice     int // Sisa frame pemberitahuan gamepad.
+	padNoticeText string
+	// Pause
+	pauseIndex      int // Pilihan menu pause yang aktif.
+	resumeCountdown int // Sisa frame hitung mundur sebelum lanjut main.
+	// Fail
+	failFade int // Sisa frame stem memudar setelah gagal.
 
 	// Audio
-	BassAudio    *audio.Player
-	GuitarAudio  *audio.Player
-	DrumsAudio   *audio.Player
-	AudioContext *audio.Context
-	GarageSFX    []byte
+	BassAudio      *audio.Player
+	GuitarAudio    *audio.Player
+	DrumsAudio     *audio.Player
+	MetronomeAudio *audio.Player
+	AudioContext   *audio.Context
+	GarageSFX      []byte
+	stretches      []*sound.TimeStretch // Time stretch di depan setiap stem.
+	clickTrack     *sound.ClickTrack
 
 	// Songs
 	// --- State Song ---
-	songChart   []*Note // Daftar semua not dalam lagu (beatmap).
-	currentTick float64 // Posisi waktu saat ini dalam lagu.
-	ticksPerSec float64 // Berapa banyak "tick" yang berlalu per detik.
+	chart       *chart.Chart
+	songChart   []*chart.Note // Daftar semua not dalam lagu (beatmap).
+	currentTick float64       // Posisi waktu saat ini dalam lagu.
+	ticksPerSec float64       // Berapa banyak "tick" yang berlalu per detik.
+	startTick   float64       // Posisi awal lagu pada run ini (setelah lead-in / skip intro).
+	firstTick   float64       // Tick not pertama.
+	lastTick    float64       // Tick not terakhir.
+	visible     []*chart.Note // Scratch untuk visibleNotes.
 
 	// --- State Game ---
-	scoreVal  int
-	score     Score
-	touchIDs  []ebiten.TouchID
-	lanes     []Instrument // Konfigurasi untuk setiap lajur.
-	noteSpeed float64      // Kecepatan not jatuh ke bawah (pixel per tick).
-	hitZoneY  float64      // Posisi Y dari zona penilaian.
-	lastFrame time.Time    // Untuk menghitung delta time.
+	run       *engine.Run   // Penilaian run pemain.
+	result    engine.Result // Hasil run saat lagu selesai.
+	ghost     *ghostRun     // Lawan ghost dari replay skor terbaik; nil jika tidak aktif.
+	lanes     []Instrument  // Konfigurasi untuk setiap lajur.
+	noteSpeed float64       // Kecepatan not jatuh ke bawah (pixel per tick).
+	hitZoneY  float64       // Posisi Y dari zona penilaian.
+	lastFrame time.Time     // Untuk menghitung delta time.
+
+	// --- Input ---
+	input    *input.Manager // Aksi lajur dan menu dari semua perangkat.
+	keyboard *device.Keyboard
+	gamepad  *device.Gamepad
+	mouse    *device.Mouse
+	touch    *device.Touch
 
 	// --- Visual ---
 	markPerfectImage  *ebiten.Image
+	markGreatImage    *ebiten.Image
 	markGoodImage     *ebiten.Image
+	markBadImage      *ebiten.Image
 	markMissImage     *ebiten.Image
 	isNoteMan1Pressed bool
 	isNoteMan2Pressed bool
 	isNoteMan3Pressed bool
-	noteMan1Image     *ebiten.Image
-	noteMan2Image     *ebiten.Image
-	noteMan3Image     *ebiten.Image
-	bgNoteImage       *ebiten.Image
-	noteImage         *ebiten.Image // Gambar untuk setiap not.
-	hitZoneLine       *ebiten.Image // Gambar untuk garis zona penilaian.
+
+	// Replay
+	recording     *replay.Replay // Input run yang sedang dimainkan.
+	replay        *replay.Replay // Replay yang sedang diputar ulang.
+	replaying     bool
+	replayInput   *input.Manager // Memutar input replay lewat replayScript.
+	replayScript  *input.Script  // Input replay sampai replaySeek.
+	replaySeek    int            // Indeks event Seek berikutnya di replay.
+	viewerPaused  bool
+	viewerSpeed   float64 // Kecepatan tonton replay: 0.5x, 1x atau 2x.
+	autoplay      bool    // Replay yang diputar dibuat oleh bot.
+	attract       bool    // Autoplay sebagai demo layar judul.
+	menuIdle      int     // Frame layar judul tanpa input.
+	noteMan1Image *ebiten.Image
+	noteMan2Image *ebiten.Image
+	noteMan3Image *ebiten.Image
+	bgNoteImage   *ebiten.Image
+	noteImage     *ebiten.Image // Gambar untuk setiap not.
+	hitZoneLine   *ebiten.Image // Gambar untuk garis zona penilaian.
 }
 
 func NewGameScene() *MainScene {
-	return &MainScene{
-		ticksPerSec: 100.0, // "BPM" virtual.
-		noteSpeed:   0.7,   // kecepatan visual not.
+	g := &MainScene{
+		ticksPerSec: chart.TicksPerSec, // "BPM" virtual.
+		noteSpeed:   0.7,               // kecepatan visual not.
 		lastFrame:   time.Now(),
-		songChart:   make([]*Note, 0),
+		songChart:   make([]*chart.Note, 0),
 		hitZoneY:    338,
+		lang:        lang.NewLanguage(lang.LanguageEN),
 	}
+	g.newInput()
+	return g
 }
 
 func (g *MainScene) ExportProperties() (prop Properties) {
@@ -149,13 +200,8 @@ func (g *MainScene) FirstLoad() {
 	g.isVeryBegin = true
 	g.state = inGameLoading
 	g.loadCount = 0
-	g.loadTotal = 25
+	g.loadTotal = 27
 	g.loadingState = 0
-	g.score = Score{
-		perfectNote: 100,
-		goodNote:    50,
-		missNote:    0, // tanpa penalty
-	}
 
 	// Set up animation initial values
 	g.garageAnimY = float64(constants.ScreenHeight)
@@ -172,7 +218,9 @@ func (g *MainScene) IsLoaded() bool {
 }
 
 func (g *MainScene) OnEnter(prop Properties) {
-
+	if prop.Lang != nil {
+		g.lang = prop.Lang
+	}
 }
 
 func (g *MainScene) OnExit() {
@@ -185,6 +233,11 @@ func (g *MainScene) Update() SceneId {
 		cX, cY := ebiten.CursorPosition()
 		fmt.Println(cX, cY)
 	}
+	// Saat bermain, input dibaca setelah waktu lagu maju supaya tekanan
+	// dinilai pada tick frame ini.
+	if g.state != inGamePlay {
+		g.pollInput(g.currentTick)
+	}
 
 	switch g.state {
 	case inGameMenu:
@@ -195,6 +248,14 @@ func (g *MainScene) Update() SceneId {
 		g.UpdateInGameFinish()
 	case inGameLoading:
 		g.UpdateInGameLoading()
+	case inGamePause:
+		g.UpdateInGamePause()
+	case inGameSettings:
+		g.UpdateInGameSettings()
+	case inGameReplay:
+		g.UpdateInGameReplay()
+	case inGameKeys:
+		g.UpdateInGameKeys()
 	}
 
 	return GameSceneId
@@ -258,21 +319,23 @@ func (g *MainScene) UpdateInGameLoading() {
 	case 3:
 		g.loadCount++
 		g.lanes = []Instrument{
-			{Key: ebiten.KeyLeft, Color: color.RGBA{150, 75, 0, 255},
-				TouchRange: image.Rect(500, 348, 536, 374),
-			}, // Soklat
-			{Key: ebiten.KeyDown, Color: color.RGBA{255, 255, 255, 255},
-				TouchRange: image.Rect(547, 348, 581, 374),
-			}, // Putih
-			{Key: ebiten.KeyRight, Color: color.RGBA{100, 255, 100, 255},
-				TouchRange: image.Rect(586, 348, 621, 374),
-			}, // Hijau
+			{Color: color.RGBA{150, 75, 0, 255}},    // Soklat
+			{Color: color.RGBA{255, 255, 255, 255}}, // Putih
+			{Color: color.RGBA{100, 255, 100, 255}}, // Hijau
 		}
 
 		g.loadCount++
-		err = json.Unmarshal(notes.Note_json, &g.songChart)
+		g.loadChart()
+
+		g.config, err = config.Load()
 		if err != nil {
-			log.Fatal(err)
+			log.Printf("config: load, using defaults: %v", err)
+		}
+		g.applyKeyBindings()
+		g.setTouchZones()
+		g.scores, err = scores.Load()
+		if err != nil {
+			log.Printf("scores: load: %v", err)
 		}
 		g.loadingState++
 
@@ -289,49 +352,40 @@ func (g *MainScene) UpdateInGameLoading() {
 
 	case 5:
 		g.loadCount++
-		gMp3, err := mp3.DecodeF32(bytes.NewReader(sounds.Guitar_mp3))
+		var ts *sound.TimeStretch
+		g.GuitarAudio, ts, err = sound.NewStemPlayer(g.AudioContext, "guitar.mp3", sounds.Guitar_mp3)
 		if err != nil {
 			log.Fatal(err)
 		}
-		sfx, err := io.ReadAll(gMp3)
-		if err != nil {
-			log.Fatal(err)
-		}
-		g.GuitarAudio = g.AudioContext.NewPlayerF32FromBytes(sfx)
 		g.GuitarAudio.SetVolume(0)
+		g.stretches = append(g.stretches, ts)
 		g.loadingState++
 
 	case 6:
 		g.loadCount++
-		dMp3, err := mp3.DecodeF32(bytes.NewReader(sounds.Drums_mp3))
+		var ts *sound.TimeStretch
+		g.DrumsAudio, ts, err = sound.NewStemPlayer(g.AudioContext, "drums.mp3", sounds.Drums_mp3)
 		if err != nil {
 			log.Fatal(err)
 		}
-		sfx, err := io.ReadAll(dMp3)
-		if err != nil {
-			log.Fatal(err)
-		}
-		g.DrumsAudio = g.AudioContext.NewPlayerF32FromBytes(sfx)
 		g.DrumsAudio.SetVolume(0)
+		g.stretches = append(g.stretches, ts)
 		g.loadingState++
 
 	case 7:
 		g.loadCount++
-		bMp3, err := mp3.DecodeF32(bytes.NewReader(sounds.Bass_mp3))
+		var ts *sound.TimeStretch
+		g.BassAudio, ts, err = sound.NewStemPlayer(g.AudioContext, "bass.mp3", sounds.Bass_mp3)
 		if err != nil {
 			log.Fatal(err)
 		}
-		sfx, err := io.ReadAll(bMp3)
-		if err != nil {
-			log.Fatal(err)
-		}
-		g.BassAudio = g.AudioContext.NewPlayerF32FromBytes(sfx)
 		g.BassAudio.SetVolume(0)
+		g.stretches = append(g.stretches, ts)
 		g.loadingState++
 
 	case 8:
 		g.loadCount++
-		grMp3, err := mp3.DecodeF32(bytes.NewReader(sounds.Garage_mp3))
+		grMp3, err := sound.Decode("garage-door.mp3", sounds.Garage_mp3)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -339,6 +393,14 @@ func (g *MainScene) UpdateInGameLoading() {
 		if err != nil {
 			log.Fatal(err)
 		}
+
+		g.loadCount++
+		g.clickTrack = g.newClickTrack()
+		g.MetronomeAudio, err = g.AudioContext.NewPlayerF32(g.clickTrack)
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.MetronomeAudio.SetVolume(g.config.MetronomeVolume)
 		g.loadingState++
 
 	case 9:
@@ -458,6 +520,10 @@ func (g *MainScene) UpdateInGameLoading() {
 			log.Fatal(err)
 		}
 		g.markMissImage = ebiten.NewImageFromImage(img)
+
+		g.loadCount++
+		g.markGreatImage = g.newMarkImage(judge.Great.String()+"!", color.RGBA{255, 170, 40, 255})
+		g.markBadImage = g.newMarkImage(judge.Bad.String(), color.RGBA{150, 150, 150, 255})
 		g.loadingState++
 
 	case 15: // Loading Complete
@@ -467,6 +533,10 @@ func (g *MainScene) UpdateInGameLoading() {
 }
 
 func (g *MainScene) UpdateInGameMenu() {
+	if g.attract && g.input.Any() {
+		g.Reset()
+		return
+	}
 
 	dt := time.Since(g.lastFrame).Seconds()
 	g.lastFrame = time.Now()
@@ -477,8 +547,27 @@ func (g *MainScene) UpdateInGameMenu() {
 	}
 
 	if !g.garageAnimActive && g.isVeryBegin {
-		g.touchIDs = ebiten.AppendTouchIDs(g.touchIDs[:0])
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) || len(g.touchIDs) > 0 {
+		if g.settingsRequested() {
+			g.openSettings()
+			return
+		}
+		if g.input.JustPressed(input.Replay) {
+			g.loadLastReplay()
+			return
+		}
+		if g.input.JustPressed(input.Autoplay) {
+			g.startAutoplay(false)
+			return
+		}
+		if g.updateAttract() {
+			return
+		}
+		if files := ebiten.DroppedFiles(); files != nil {
+			g.openDroppedReplay(files)
+			return
+		}
+
+		if g.input.JustPressed(input.Confirm) || g.input.JustPressed(input.Tap) {
 
 			g.garageAnimActive = true
 			g.isVeryBegin = false
@@ -507,17 +596,71 @@ func (g *MainScene) UpdateInGameMenu() {
 		g.doorAnimY -= speed
 		if g.doorAnimY < (-constants.ScreenHeight / 2) {
 			g.doorAnimY = -constants.ScreenHeight / 2
-			g.state = inGamePlay
 			g.doorAnimActive = false
-			g.BassAudio.Rewind()
-			g.GuitarAudio.Rewind()
-			g.DrumsAudio.Rewind()
-			g.lastFrame = time.Now().Add(-time.Duration(6.5 * float64(time.Second)))
+			g.startSong()
 		}
 	}
 
 }
 func (g *MainScene) UpdateInGamePlay() {
+	if g.run.Failed {
+		g.pollInput(g.currentTick)
+		g.updateFail()
+		return
+	}
+
+	g.updateBand()
+
+	// Hitung delta time untuk pergerakan yang konsisten.
+	dt := time.Since(g.lastFrame).Seconds()
+	g.lastFrame = time.Now()
+
+	g.skyOffset -= 20 * dt
+	if g.skyOffset <= -constants.ScreenWidth {
+		g.skyOffset += constants.ScreenWidth
+	}
+
+	// Majukan posisi waktu lagu. Tick dibulatkan ke resolusi replay supaya
+	// miss dan tekanan dinilai pada tick yang sama saat diputar ulang.
+	g.currentTick = replay.Quantize(g.currentTick + g.ticksPerSec*dt*g.run.Rate)
+	g.pollInput(g.currentTick)
+	if g.state != inGamePlay {
+		return // Di-pause karena gamepad terputus.
+	}
+	if g.input.JustPressed(input.Pause) || !ebiten.IsFocused() || g.pauseTapped() {
+		g.pause()
+		return
+	}
+
+	g.updateLaneInput()
+	g.advanceNotes(g.currentTick)
+
+	if g.lastTick+finishDelayTicks < g.currentTick {
+		g.finishRun()
+		g.state = inGameFinish
+		return
+	}
+
+	g.updateGhost(g.currentTick)
+
+	if !g.BassAudio.IsPlaying() && g.currentTick >= g.chart.AudioStart {
+		for _, p := range g.songPlayers() {
+			if err := p.SetPosition(g.songPosition()); err != nil {
+				log.Printf("audio: seek stem: %v", err)
+			}
+		}
+		for _, p := range g.songPlayers() {
+			p.Play()
+		}
+		g.BassAudio.SetVolume(1)
+		g.GuitarAudio.SetVolume(1)
+		g.DrumsAudio.SetVolume(1)
+	}
+	g.syncClick()
+}
+
+// updateBand menjalankan animasi band dan menghapus mark yang sudah habis waktunya.
+func (g *MainScene) updateBand() {
 	if g.Man1.IsMark {
 		g.Man1.CurrentMarkTime++
 		if g.Man1.CurrentMarkTime > g.Man1.MarkTime {
@@ -543,174 +686,67 @@ func (g *MainScene) UpdateInGamePlay() {
 	g.Man1.Animations.Update()
 	g.Man2.Animations.Update()
 	g.Man3.Animations.Update()
+}
 
-	// Hitung delta time untuk pergerakan yang konsisten.
-	dt := time.Since(g.lastFrame).Seconds()
-	g.lastFrame = time.Now()
-
-	g.skyOffset -= 20 * dt
-	if g.skyOffset <= -constants.ScreenWidth {
-		g.skyOffset += constants.ScreenWidth
-	}
-
-	// Majukan posisi waktu lagu.
-	g.currentTick += g.ticksPerSec * dt
-	// Perbarui posisi Y setiap not dan cek jika terlewat.
-	highestTick := 0.0
-	for _, note := range g.songChart {
-		if note.Tick > highestTick {
-			highestTick = note.Tick
-		}
-		if !note.IsActive {
-			continue
-		}
-
-		// Hitung posisi Y berdasarkan seberapa jauh not dari waktu saat ini.
-		// Not akan berada di hitZoneY saat note.Tick == g.currentTick.
-		tickDifference := note.Tick - g.currentTick
-		note.YPosition = g.hitZoneY - (tickDifference * g.noteSpeed)
-
-		// Cek jika not terlewat (sudah melewati zona penilaian).
-		if note.YPosition > (NoteY + NoteHeight) {
-			note.IsActive = false
-
-			g.scoreVal += int(g.score.missNote)
-			switch note.Lane {
-			case GuitarLaneId:
-				g.score.guitarScore.miss += 1
-				g.GuitarAudio.SetVolume(0)
-				g.Man1.MarkImage = g.markMissImage
-				g.Man1.IsMark = true
-				g.Man1.CurrentMarkTime = 0
-			case DrumsLaneId:
-				g.score.drumScore.miss += 1
-				g.DrumsAudio.SetVolume(0)
-				g.Man3.MarkImage = g.markMissImage
-				g.Man3.IsMark = true
-				g.Man3.CurrentMarkTime = 0
-			case BassLaneId:
-				g.score.bassScore.miss += 1
-				g.BassAudio.SetVolume(0)
-				g.Man2.MarkImage = g.markMissImage
-				g.Man2.IsMark = true
-				g.Man2.CurrentMarkTime = 0
-			}
+// advanceNotes memperbarui posisi Y not yang terlihat dan menilai miss not
+// yang sudah melewati window penilaian terakhir pada tick.
+func (g *MainScene) advanceNotes(tick float64) {
+	g.run.Advance(tick, g.lookAhead(), g.showHit)
+	for i := range engine.LaneCount {
+		for _, note := range g.run.Visible(i) {
+			// Not akan berada di hitZoneY saat note.Tick == g.currentTick.
+			note.YPosition = g.hitZoneY - (note.Tick-g.currentTick)*g.noteSpeed
 		}
 	}
+}
 
-	if highestTick+300 < g.currentTick {
-		g.state = inGameFinish
+// visibleNotes mengumpulkan not terlihat dari semua lajur.
+func (g *MainScene) visibleNotes() []*chart.Note {
+	g.visible = g.visible[:0]
+	for i := range engine.LaneCount {
+		g.visible = append(g.visible, g.run.Visible(i)...)
 	}
+	return g.visible
+}
 
-	// Handle input
-	// Toleransi waktu untuk penilaian (dalam tick).
-	perfectWindow := 10.0
-	goodWindow := 20.0
-
-	cX, cY := 0, 0
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		cX, cY = ebiten.CursorPosition()
-	}
-	g.touchIDs = ebiten.AppendTouchIDs(g.touchIDs[:0])
-	if len(g.touchIDs) > 0 {
-		cX, cY = ebiten.TouchPosition(g.touchIDs[0])
-	}
-	cs := image.Rect(cX, cY, cX+5, cY+5)
+// lookAhead adalah jarak (tick) not masuk layar sebelum sampai zona penilaian.
+func (g *MainScene) lookAhead() float64 {
+	return (g.hitZoneY - NoteY) / g.noteSpeed
+}
 
-	g.isNoteMan1Pressed = false
-	g.isNoteMan2Pressed = false
-	g.isNoteMan3Pressed = false
-	if ebiten.IsKeyPressed(ebiten.KeyLeft) || cs.In(g.lanes[0].TouchRange) {
-		g.isNoteMan1Pressed = true
-	}
-	if ebiten.IsKeyPressed(ebiten.KeyRight) || cs.In(g.lanes[2].TouchRange) {
-		g.isNoteMan2Pressed = true
-	}
-	if ebiten.IsKeyPressed(ebiten.KeyDown) || cs.In(g.lanes[1].TouchRange) {
-		g.isNoteMan3Pressed = true
+// pressLane menilai tekanan lajur i pada posisi tick. Input pemain dan
+// replay sama-sama lewat sini, jadi keduanya menghasilkan skor yang sama.
+func (g *MainScene) pressLane(i int, tick float64) {
+	if hit, ok := g.run.Press(i, tick); ok {
+		g.showHit(hit)
 	}
+}
 
-	for i, lane := range g.lanes {
-		// Cek jika tombol untuk lajur ini baru saja ditekan.
-		if inpututil.IsKeyJustPressed(lane.Key) || cs.In(lane.TouchRange) {
-			var bestNote *Note
-			minTickDiff := math.Inf(1)
-
-			// Cari not aktif terdekat di lajur yang ditekan.
-			for _, note := range g.songChart {
-				if note.IsActive && int(note.Lane) == i {
-					tickDiff := math.Abs(note.Tick - g.currentTick)
-					if tickDiff < minTickDiff {
-						minTickDiff = tickDiff
-						bestNote = note
-						break
-					}
-				}
-			}
-
-			// Jika ada not yang ditemukan dalam jangkauan.
-			if bestNote != nil {
-				if minTickDiff <= perfectWindow {
-					g.scoreVal += int(g.score.perfectNote)
-					bestNote.IsActive = false
-					switch bestNote.Lane {
-					case GuitarLaneId:
-						g.score.guitarScore.perfect += 1
-						g.GuitarAudio.SetVolume(1)
-						g.Man1.MarkImage = g.markPerfectImage
-						g.Man1.IsMark = true
-						g.Man1.CurrentMarkTime = 0
-					case DrumsLaneId:
-						g.score.drumScore.perfect += 1
-						g.DrumsAudio.SetVolume(1)
-						g.Man3.MarkImage = g.markPerfectImage
-						g.Man3.IsMark = true
-						g.Man3.CurrentMarkTime = 0
-					case BassLaneId:
-						g.score.bassScore.perfect += 1
-						g.BassAudio.SetVolume(1)
-						g.Man2.MarkImage = g.markPerfectImage
-						g.Man2.IsMark = true
-						g.Man2.CurrentMarkTime = 0
-					}
-				} else if minTickDiff <= goodWindow {
-					g.scoreVal += int(g.score.goodNote)
-					bestNote.IsActive = false
-					switch bestNote.Lane {
-					case GuitarLaneId:
-						g.score.guitarScore.good += 1
-						g.GuitarAudio.SetVolume(1)
-						g.Man1.MarkImage = g.markGoodImage
-						g.Man1.IsMark = true
-						g.Man1.CurrentMarkTime = 0
-					case DrumsLaneId:
-						g.score.drumScore.good += 1
-						g.DrumsAudio.SetVolume(1)
-						g.Man3.MarkImage = g.markGoodImage
-						g.Man3.IsMark = true
-						g.Man3.CurrentMarkTime = 0
-					case BassLaneId:
-						g.score.bassScore.good += 1
-						g.BassAudio.SetVolume(1)
-						g.Man2.MarkImage = g.markGoodImage
-						g.Man2.IsMark = true
-						g.Man2.CurrentMarkTime = 0
-					}
-				}
-			}
+// updateLaneInput menilai setiap aksi lajur dari semua sumber input dan
+// merekamnya ke replay. Not dimajukan dulu ke waktu event, sama seperti saat
+// replay diputar ulang.
+func (g *MainScene) updateLaneInput() {
+	tick := replay.Quantize(g.currentTick)
+	for i := range g.lanes {
+		held := g.input.Held(input.Lane(i))
+		if *g.laneHeld(i) && !held {
+			g.recording.Release(i, tick)
 		}
+		*g.laneHeld(i) = held
 	}
 
-	if !g.BassAudio.IsPlaying() {
-		g.BassAudio.Play()
-		g.GuitarAudio.Play()
-		g.DrumsAudio.Play()
-		g.BassAudio.SetVolume(1)
-		g.GuitarAudio.SetVolume(1)
-		g.DrumsAudio.SetVolume(1)
+	for _, e := range g.input.Events() {
+		lane, ok := e.Action.LaneIndex()
+		if !ok || !e.Pressed || lane >= len(g.lanes) {
+			continue
+		}
+		at := replay.Quantize(e.At)
+		g.advanceNotes(at)
+		g.recording.Press(lane, at)
+		g.pressLane(lane, at)
 	}
-
 }
+
 func (g *MainScene) UpdateInGameFinish() {
 	g.Man1.Animations.Update()
 	g.Man2.Animations.Update()
@@ -736,33 +772,32 @@ func (g *MainScene) UpdateInGameFinish() {
 	}
 
 	if g.isFinishAnim {
-		g.touchIDs = ebiten.AppendTouchIDs(g.touchIDs[:0])
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) || len(g.touchIDs) > 0 {
+		if g.input.JustPressed(input.Replay) {
+			if g.autoplay {
+				g.startAutoplay(false)
+				return
+			}
+			r := g.recording
+			if g.replaying {
+				r = g.replay
+			}
+			g.startReplay(r)
+			return
+		}
+		if g.input.JustPressed(input.Confirm) || g.input.JustPressed(input.Tap) {
 			g.Reset()
 		}
 	}
 }
 
 func (g *MainScene) Reset() {
-	// sound
-	g.BassAudio.Pause()
-	g.GuitarAudio.Pause()
-	g.DrumsAudio.Pause()
-	g.BassAudio.Rewind()
-	g.GuitarAudio.Rewind()
-	g.DrumsAudio.Rewind()
-
-	// scoring
-	g.scoreVal = 0
-	g.score.guitarScore.perfect = 0
-	g.score.guitarScore.good = 0
-	g.score.guitarScore.miss = 0
-	g.score.drumScore.perfect = 0
-	g.score.drumScore.good = 0
-	g.score.drumScore.miss = 0
-	g.score.bassScore.perfect = 0
-	g.score.bassScore.good = 0
-	g.score.bassScore.miss = 0
+	g.stopSong()
+	g.resetRun()
+	g.replaying = false
+	g.autoplay = false
+	g.attract = false
+	g.menuIdle = 0
+
 	// reset menu
 	g.isVeryBegin = true
 	g.garageAnimY = float64(constants.ScreenHeight)
@@ -770,13 +805,6 @@ func (g *MainScene) Reset() {
 	g.doorAnimY = 0
 	g.doorAnimActive = false
 
-	// reset gameplay
-	err := json.Unmarshal(notes.Note_json, &g.songChart)
-	if err != nil {
-		log.Fatal(err)
-	}
-	g.currentTick = 0
-
 	// reset finish
 	g.finishAnimY = 0
 	g.finishAnimActive = true
@@ -785,6 +813,97 @@ func (g *MainScene) Reset() {
 	// change state
 	g.state = inGameMenu
 }
+
+// startSong memulai lagu dari awal chart, termasuk lead-in. Jika SkipIntro
+// aktif, lagu langsung dimulai beberapa ketukan hitung mundur sebelum not
+// pertama.
+func (g *MainScene) startSong() {
+	cfg := g.runConfig()
+	g.state = inGamePlay
+	if g.replaying {
+		g.state = inGameReplay
+	}
+	g.run = engine.New(g.chart, cfg)
+	g.startTick = g.chart.StartTick()
+	if cfg.SkipIntro {
+		skipTo := g.chart.FirstTick() - skipIntroBeats*g.countdownBeatTicks()
+		if skipTo > g.startTick {
+			g.startTick = skipTo
+		}
+	}
+	g.currentTick = g.startTick
+	g.lastFrame = time.Now()
+	g.startRecording()
+	g.resetPointers()
+	g.startGhost()
+
+	for _, ts := range g.stretches {
+		if err := ts.SetRate(g.run.Rate); err != nil {
+			log.Printf("audio: set song rate: %v", err)
+		}
+	}
+	g.clickTrack.SetRate(g.run.Rate)
+	g.syncStems()
+}
+
+// syncStems menyamakan posisi stem dengan currentTick. Stem hanya diputar
+// jika lagu sudah melewati AudioStart.
+func (g *MainScene) syncStems() {
+	pos := g.songPosition()
+	for _, p := range g.songPlayers() {
+		p.Pause()
+		if err := p.SetPosition(pos); err != nil {
+			log.Printf("audio: sync stem to %v: %v", pos, err)
+		}
+	}
+	if g.currentTick >= g.chart.AudioStart {
+		for _, p := range g.songPlayers() {
+			p.Play()
+		}
+	}
+}
+
+// songPosition adalah posisi stem yang sesuai dengan currentTick.
+func (g *MainScene) songPosition() time.Duration {
+	sec := (g.currentTick - g.chart.AudioStart) / g.ticksPerSec / g.run.Rate
+	if sec < 0 {
+		return 0
+	}
+	return time.Duration(sec * float64(time.Second))
+}
+
+func (g *MainScene) stopSong() {
+	for _, p := range g.songPlayers() {
+		p.Pause()
+		p.Rewind()
+	}
+}
+
+// songPlayers mengembalikan semua player yang mengikuti posisi lagu: ketiga
+// stem dan metronome.
+func (g *MainScene) songPlayers() []*audio.Player {
+	return []*audio.Player{g.GuitarAudio, g.DrumsAudio, g.BassAudio, g.MetronomeAudio}
+}
+
+// resetRun mengosongkan skor dan memuat ulang chart.
+func (g *MainScene) resetRun() {
+	g.MetronomeAudio.SetVolume(g.config.MetronomeVolume)
+
+	// reset gameplay
+	g.loadChart()
+	g.currentTick = 0
+}
+
+func (g *MainScene) loadChart() {
+	c, err := chart.Parse(notes.Note_json)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g.chart = c
+	g.songChart = c.Notes
+	g.firstTick = c.FirstTick()
+	g.lastTick = c.LastTick()
+}
 func (g *MainScene) Draw(screen *ebiten.Image) {
 
 	screen.Fill(color.RGBA{164, 210, 217, 255})
@@ -798,7 +917,16 @@ func (g *MainScene) Draw(screen *ebiten.Image) {
 		g.DrawInGameFinish(screen)
 	case inGameLoading:
 		g.DrawInGameLoading(screen)
+	case inGamePause:
+		g.DrawInGamePause(screen)
+	case inGameSettings:
+		g.DrawInGameSettings(screen)
+	case inGameReplay:
+		g.DrawInGameReplay(screen)
+	case inGameKeys:
+		g.DrawInGameKeys(screen)
 	}
+	g.drawPadNotice(screen)
 }
 
 func (g *MainScene) DrawInGameLoading(screen *ebiten.Image) {
@@ -825,6 +953,19 @@ func (g *MainScene) DrawInGameLoading(screen *ebiten.Image) {
 	}
 }
 
+// drawText menggambar teks dengan font game.
+func (g *MainScene) drawText(screen *ebiten.Image, texts string, fontSize, x, y float64, align text.Align, clr color.Color) {
+	opt := &text.DrawOptions{}
+	opt.GeoM.Translate(x, y)
+	opt.ColorScale.ScaleWithColor(clr)
+	opt.LineSpacing = fontSize * 1.2
+	opt.PrimaryAlign = align
+	text.Draw(screen, texts, &text.GoTextFace{
+		Source: g.fontSource,
+		Size:   fontSize,
+	}, opt)
+}
+
 func (g *MainScene) DrawInGameMenu(screen *ebiten.Image) {
 
 	op := &ebiten.DrawImageOptions{}
@@ -919,6 +1060,11 @@ func (g *MainScene) DrawInGameMenu(screen *ebiten.Image) {
 			Size:   fontSize,
 		}, opt)
 		opt.GeoM.Reset()
+
+		g.drawMenuBest(screen)
+		g.drawText(screen, g.lang.Autoplay()+" (A)", 18, float64(settingsButton.Max.X-15), float64(settingsButton.Min.Y-38), text.AlignEnd, color.Black)
+		g.drawText(screen, g.lang.Replay(), 18, float64(settingsButton.Max.X-15), float64(settingsButton.Min.Y-14), text.AlignEnd, color.Black)
+		g.drawText(screen, g.lang.Settings()+" (Tab)", 18, float64(settingsButton.Max.X-15), float64(settingsButton.Min.Y+10), text.AlignEnd, color.Black)
 	}
 
 }
@@ -951,6 +1097,7 @@ func (g *MainScene) DrawInGamePlay(screen *ebiten.Image) {
 		op.GeoM.Translate(g.Man3.X+18, g.Man3.Y-30)
 		screen.DrawImage(g.Man3.MarkImage, op)
 		op.GeoM.Reset()
+		g.drawMarkText(screen, &g.Man3, g.Man3.X+18, g.Man3.Y-30)
 	}
 
 	op.GeoM.Translate(g.Man1.X, g.Man1.Y)
@@ -963,6 +1110,7 @@ func (g *MainScene) DrawInGamePlay(screen *ebiten.Image) {
 		op.GeoM.Translate(g.Man1.X+8, g.Man1.Y-15)
 		screen.DrawImage(g.Man1.MarkImage, op)
 		op.GeoM.Reset()
+		g.drawMarkText(screen, &g.Man1, g.Man1.X+8, g.Man1.Y-15)
 	}
 
 	op.GeoM.Translate(g.Man2.X, g.Man2.Y)
@@ -975,6 +1123,7 @@ func (g *MainScene) DrawInGamePlay(screen *ebiten.Image) {
 		op.GeoM.Translate(g.Man2.X+13, g.Man2.Y-15)
 		screen.DrawImage(g.Man2.MarkImage, op)
 		op.GeoM.Reset()
+		g.drawMarkText(screen, &g.Man2, g.Man2.X+13, g.Man2.Y-15)
 	}
 
 	// note
@@ -1041,19 +1190,19 @@ func (g *MainScene) DrawInGamePlay(screen *ebiten.Image) {
 		op.GeoM.Translate(float64(x), g.hitZoneY-float64(g.noteImage.Bounds().Dy())/2)
 		op.ColorScale.ScaleWithColor(lane.Color)
 		switch i {
-		case int(GuitarLaneId):
+		case int(chart.GuitarLaneId):
 			if g.isNoteMan1Pressed {
 				op.ColorScale.ScaleAlpha(0.8)
 			} else {
 				op.ColorScale.ScaleAlpha(0.4) // Buat lebih transparan
 			}
-		case int(BassLaneId):
+		case int(chart.BassLaneId):
 			if g.isNoteMan2Pressed {
 				op.ColorScale.ScaleAlpha(0.8)
 			} else {
 				op.ColorScale.ScaleAlpha(0.4) // Buat lebih transparan
 			}
-		case int(DrumsLaneId):
+		case int(chart.DrumsLaneId):
 			if g.isNoteMan3Pressed {
 				op.ColorScale.ScaleAlpha(0.8)
 			} else {
@@ -1063,12 +1212,12 @@ func (g *MainScene) DrawInGamePlay(screen *ebiten.Image) {
 		screen.DrawImage(g.noteImage, op)
 	}
 
-	// Gambar setiap not yang masih aktif.
-	for _, note := range g.songChart {
+	// Gambar setiap not yang masih aktif dan terlihat.
+	for _, note := range g.visibleNotes() {
 		if !note.IsActive {
 			continue
 		}
-		if note.YPosition < NoteY {
+		if note.YPosition < NoteY || note.YPosition > NoteY+NoteHeight {
 			continue
 		}
 		op := &ebiten.DrawImageOptions{}
@@ -1082,6 +1231,15 @@ func (g *MainScene) DrawInGamePlay(screen *ebiten.Image) {
 
 		screen.DrawImage(g.noteImage, op)
 	}
+
+	g.drawCombo(screen)
+	g.drawBeatPulse(screen)
+	g.drawCountdown(screen)
+	g.drawHealth(screen)
+	g.drawReplayLabel(screen)
+	g.drawGhost(screen)
+	g.drawTouchZones(screen)
+	g.drawPauseButton(screen)
 }
 func (g *MainScene) DrawInGameFinish(screen *ebiten.Image) {
 	op := &ebiten.DrawImageOptions{}
@@ -1130,213 +1288,58 @@ func (g *MainScene) DrawInGameFinish(screen *ebiten.Image) {
 	// panel
 	if g.isFinishAnim {
 		x := 180.0
-		y := 70.0
+		y := 60.0
 		scale := 1.0 / 1.3
 
 		// instrument
-		op.GeoM.Translate(0, 0)
-		op.GeoM.Scale(scale, scale)
-		op.GeoM.Translate(x, y)
-		screen.DrawImage(g.noteMan1Image, op)
-		op.GeoM.Reset()
-
-		x += 135
-		op.GeoM.Translate(0, 0)
-		op.GeoM.Scale(scale, scale)
-		op.GeoM.Translate(x, y)
-		screen.DrawImage(g.noteMan3Image, op)
-		op.GeoM.Reset()
-
-		x += 130
-		op.GeoM.Translate(0, 0)
-		op.GeoM.Scale(scale, scale)
-		op.GeoM.Translate(x, y)
-		screen.DrawImage(g.noteMan2Image, op)
-		op.GeoM.Reset()
-
-		// mark
-		x = 70
-		y = 155
-		op.GeoM.Translate(x, y)
-		screen.DrawImage(g.markPerfectImage, op)
-		op.GeoM.Reset()
-
-		y += 65
-		op.GeoM.Translate(x, y)
-		screen.DrawImage(g.markGoodImage, op)
-		op.GeoM.Reset()
-
-		y += 65
-		op.GeoM.Translate(x, y)
-		screen.DrawImage(g.markMissImage, op)
-		op.GeoM.Reset()
+		for _, icon := range []*ebiten.Image{g.noteMan1Image, g.noteMan3Image, g.noteMan2Image} {
+			op.GeoM.Scale(scale, scale)
+			op.GeoM.Translate(x, y)
+			screen.DrawImage(icon, op)
+			op.GeoM.Reset()
+			x += 130
+		}
 
 		// scoring
-		x1 := 200.0
-		x2 := 335.0
-		x3 := 460.0
-		y1 := 160.0
-		y2 := 225.0
-		y3 := 285.0
-		var fontSize float64 = 32
-		opt := &text.DrawOptions{}
-
-		// guitar
-		texts := fmt.Sprintf("X%d", g.score.guitarScore.perfect)
-		opt.GeoM.Translate(x1, y1)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
-		texts = fmt.Sprintf("X%d", g.score.guitarScore.good)
-		opt.GeoM.Translate(x1, y2)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
-		texts = fmt.Sprintf("X%d", g.score.guitarScore.miss)
-		opt.GeoM.Translate(x1, y3)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
-
-		// drums
-		texts = fmt.Sprintf("X%d", g.score.drumScore.perfect)
-		opt.GeoM.Translate(x2, y1)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
-		texts = fmt.Sprintf("X%d", g.score.drumScore.good)
-		opt.GeoM.Translate(x2, y2)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
-		texts = fmt.Sprintf("X%d", g.score.drumScore.miss)
-		opt.GeoM.Translate(x2, y3)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
-
-		// bass
-		texts = fmt.Sprintf("X%d", g.score.bassScore.perfect)
-		opt.GeoM.Translate(x3, y1)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
-		texts = fmt.Sprintf("X%d", g.score.bassScore.good)
-		opt.GeoM.Translate(x3, y2)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
-		texts = fmt.Sprintf("X%d", g.score.bassScore.miss)
-		opt.GeoM.Translate(x3, y3)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
+		columns := g.run.Lanes // Gitar, drum, bass.
+		fontSize := 24.0
+		y = finishRowY
+		for j := judge.Perfect; j < judge.JudgementCount; j++ {
+			op.GeoM.Scale(0.7, 0.7)
+			op.GeoM.Translate(90, y)
+			screen.DrawImage(g.markImage(j), op)
+			op.GeoM.Reset()
 
-		x3 += 80
-		finalScore := (g.score.guitarScore.perfect + g.score.drumScore.perfect + g.score.bassScore.perfect) * int(g.score.perfectNote)
-		texts = fmt.Sprintf("=    %d", finalScore)
-		opt.GeoM.Translate(x3, y1)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
+			x = 200
+			for _, c := range columns {
+				g.drawText(screen, fmt.Sprintf("X%d", c[j]), fontSize, x, y, text.AlignStart, color.Black)
+				x += 130
+			}
+			g.drawText(screen, fmt.Sprintf("=    %d", g.result.Points[j]), fontSize, x-30, y, text.AlignStart, color.Black)
+			y += finishRowGap
+		}
 
-		finalScore = (g.score.guitarScore.good + g.score.drumScore.good + g.score.bassScore.good) * int(g.score.goodNote)
-		texts = fmt.Sprintf("=    %d", finalScore)
-		opt.GeoM.Translate(x3, y2)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
+		g.drawText(screen, fmt.Sprintf("TOTAL SCORE   %d", g.result.Score), 36, 335, y+5, text.AlignCenter, color.Black)
 
-		finalScore = (g.score.guitarScore.miss + g.score.drumScore.miss + g.score.bassScore.miss) * int(g.score.missNote)
-		texts = fmt.Sprintf("=    %d", finalScore)
-		opt.GeoM.Translate(x3, y3)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
+		g.drawTimingHistogram(screen)
+		g.drawComboResult(screen)
+		g.drawGrade(screen)
+		g.drawResultBest(screen)
+		g.drawReplayResult(screen)
 
-		fontSize *= 1.5
-		texts = fmt.Sprintf("TOTAL SCORE   %d", g.scoreVal)
-		opt.GeoM.Translate(x2, y3+50)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.5
-		opt.PrimaryAlign = text.AlignCenter
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
+		modifiers := g.run.Rule.Name() + "   " + g.run.Preset.String()
+		if g.run.Rate != 1 {
+			modifiers += fmt.Sprintf("   x%.1f", g.run.Rate)
+		}
+		if g.run.NoFail {
+			modifiers += "   No Fail"
+		}
+		if g.run.GhostTapPenalty {
+			modifiers += "   Ghost Tap"
+		}
+		g.drawText(screen, modifiers, 14, 20, 368, text.AlignStart, color.Black)
 
-		fontSize = 14
-		texts = "Press Enter/Click/Touch\nFor Back To Menu"
-		opt.GeoM.Translate(565, 350)
-		opt.ColorScale.ScaleWithColor(color.Black)
-		opt.LineSpacing = fontSize * 1.2
-		opt.PrimaryAlign = text.AlignStart
-		text.Draw(screen, texts, &text.GoTextFace{
-			Source: g.fontSource,
-			Size:   fontSize,
-		}, opt)
-		opt.GeoM.Reset()
+		g.drawText(screen, "Press Enter/Click/Touch\nFor Back To Menu", 14, 565, 350, text.AlignStart, color.Black)
 	}
 }
 
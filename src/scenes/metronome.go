@@ -0,0 +1,67 @@
+package scenes
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/rizalmf/old-boys/assets/sounds"
+	"github.com/rizalmf/old-boys/src/sound"
+)
+
+// clickDrift adalah selisih terbesar metronome dengan stem sebelum disamakan.
+const clickDrift = 5 * time.Millisecond
+
+// newClickTrack membuat track metronome dari timing point chart. Posisi
+// setiap ketukan dihitung dalam sample stem, bukan frame game. Chart tanpa
+// tempo mendapat track kosong.
+func (g *MainScene) newClickTrack() *sound.ClickTrack {
+	if !g.chart.HasTempo() {
+		return sound.NewClickTrack(nil)
+	}
+	ticks := g.chart.Beats(g.chart.AudioStart, g.chart.LastTick()+finishDelayTicks)
+	frames := make([]int64, 0, len(ticks))
+	for _, t := range ticks {
+		sec := (t - g.chart.AudioStart) / g.ticksPerSec
+		frames = append(frames, int64(math.Round(sec*float64(sounds.Rates))))
+	}
+	return sound.NewClickTrack(frames)
+}
+
+// syncClick memindahkan metronome ke posisi stem jika keduanya bergeser
+// lebih dari clickDrift. Metronome diputar player sendiri, jadi selain
+// disamakan di syncStems, posisinya juga dicek setiap frame.
+func (g *MainScene) syncClick() {
+	if !g.MetronomeAudio.IsPlaying() || !g.BassAudio.IsPlaying() {
+		return
+	}
+	pos := g.BassAudio.Position()
+	if (g.MetronomeAudio.Position() - pos).Abs() <= clickDrift {
+		return
+	}
+	if err := g.MetronomeAudio.SetPosition(pos); err != nil {
+		log.Printf("audio: resync metronome to %v: %v", pos, err)
+	}
+}
+
+// beatPulse bernilai 1 tepat di ketukan lalu memudar sampai ketukan berikutnya.
+func (g *MainScene) beatPulse() float64 {
+	tp := g.chart.TimingPointAt(g.currentTick)
+	beats := (g.currentTick - tp.Tick) / g.chart.BeatTicks(g.currentTick)
+	return 1 - (beats - math.Floor(beats))
+}
+
+// drawBeatPulse menyalakan garis zona penilaian mengikuti ketukan metronome.
+func (g *MainScene) drawBeatPulse(screen *ebiten.Image) {
+	if g.config.MetronomeVolume <= 0 || !g.chart.HasTempo() || g.currentTick < g.chart.AudioStart {
+		return
+	}
+	pulse := g.beatPulse()
+	for i := range g.lanes {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(firstNoteX+noteLineWidth*i), g.hitZoneY-float64(g.hitZoneLine.Bounds().Dy())/2)
+		op.ColorScale.ScaleAlpha(float32(0.3 + 0.7*pulse*pulse))
+		screen.DrawImage(g.hitZoneLine, op)
+	}
+}
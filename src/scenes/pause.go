@@ -0,0 +1,153 @@
+package scenes
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/input"
+	"github.com/rizalmf/old-boys/src/replay"
+)
+
+const (
+	pauseResume = iota
+	pauseRestart
+	pauseQuit
+	pauseOptionCount
+)
+
+const (
+	resumeCountdownFrames = 3 * constants.TPS
+	rewindOnResume        = 2 * time.Second
+	pauseOptionY          = 170
+	pauseOptionGap        = 45
+)
+
+// pauseButton adalah tombol pause di pojok kiri atas untuk mouse dan layar
+// sentuh. Tidak ada layout area sentuh lajur yang menutupinya.
+var pauseButton = image.Rect(15, 10, 47, 42)
+
+func (g *MainScene) pause() {
+	g.state = inGamePause
+	g.pauseIndex = pauseResume
+	g.resumeCountdown = 0
+	for _, p := range g.songPlayers() {
+		p.Pause()
+	}
+}
+
+// resume memundurkan lagu beberapa detik waktu nyata, jadi sesuai kecepatan
+// lagu, lalu mulai hitung mundur 3-2-1.
+// Not yang sudah lewat sebelum pause dinilai miss dulu, lalu rewind direkam
+// ke replay.
+func (g *MainScene) resume() {
+	g.resumeCountdown = resumeCountdownFrames
+	from := replay.Quantize(g.currentTick)
+	g.advanceNotes(from)
+	shift := min(rewindOnResume.Seconds()*g.ticksPerSec*g.run.Rate, g.currentTick-g.startTick)
+	g.currentTick = replay.Quantize(g.currentTick - max(shift, 0))
+	g.recording.Seek(from, g.currentTick)
+}
+
+func (g *MainScene) UpdateInGamePause() {
+	if g.resumeCountdown > 0 {
+		g.resumeCountdown--
+		if g.resumeCountdown == 0 {
+			g.state = inGamePlay
+			g.lastFrame = time.Now()
+			g.syncStems()
+		}
+		return
+	}
+
+	if g.input.JustPressed(input.Pause) {
+		g.resume()
+		return
+	}
+	if g.input.JustPressed(input.Up) {
+		g.pauseIndex = (g.pauseIndex + pauseOptionCount - 1) % pauseOptionCount
+	}
+	if g.input.JustPressed(input.Down) {
+		g.pauseIndex = (g.pauseIndex + 1) % pauseOptionCount
+	}
+
+	selected := g.input.JustPressed(input.Confirm)
+	for _, pt := range g.input.Taps() {
+		for i := range pauseOptionCount {
+			if pt.In(pauseOptionRect(i)) {
+				g.pauseIndex = i
+				selected = true
+			}
+		}
+	}
+	if !selected {
+		return
+	}
+
+	switch g.pauseIndex {
+	case pauseResume:
+		g.resume()
+	case pauseRestart:
+		g.stopSong()
+		g.resetRun()
+		g.startSong()
+	case pauseQuit:
+		g.Reset()
+	}
+}
+
+// pauseTapped benar jika tombol pause diklik atau disentuh frame ini.
+func (g *MainScene) pauseTapped() bool {
+	for _, pt := range g.input.Taps() {
+		if pt.In(pauseButton) {
+			return true
+		}
+	}
+	return false
+}
+
+// drawPauseButton menggambar tombol pause saat sedang bermain.
+func (g *MainScene) drawPauseButton(screen *ebiten.Image) {
+	if g.state != inGamePlay || g.replaying || g.run.Failed {
+		return
+	}
+	x, y := float32(pauseButton.Min.X), float32(pauseButton.Min.Y)
+	w, h := float32(pauseButton.Dx()), float32(pauseButton.Dy())
+	vector.DrawFilledRect(screen, x, y, w, h, color.RGBA{24, 24, 24, 160}, false)
+	vector.StrokeRect(screen, x, y, w, h, 1, color.Black, false)
+	vector.DrawFilledRect(screen, x+w*0.3, y+h*0.25, w*0.13, h*0.5, color.White, false)
+	vector.DrawFilledRect(screen, x+w*0.57, y+h*0.25, w*0.13, h*0.5, color.White, false)
+}
+
+func pauseOptionRect(i int) image.Rectangle {
+	y := pauseOptionY + pauseOptionGap*i
+	return image.Rect(constants.ScreenWidth/2-120, y, constants.ScreenWidth/2+120, y+pauseOptionGap)
+}
+
+func (g *MainScene) DrawInGamePause(screen *ebiten.Image) {
+	g.DrawInGamePlay(screen)
+
+	vector.DrawFilledRect(screen, 0, 0, constants.ScreenWidth, constants.ScreenHeight, color.RGBA{255, 255, 255, 150}, false)
+
+	cx := float64(constants.ScreenWidth / 2)
+	if g.resumeCountdown > 0 {
+		n := (g.resumeCountdown + constants.TPS - 1) / constants.TPS
+		g.drawText(screen, fmt.Sprintf("%d", n), 90, cx, 140, text.AlignCenter, color.Black)
+		return
+	}
+
+	g.drawText(screen, g.lang.Paused(), 56, cx, 80, text.AlignCenter, color.Black)
+	options := []string{g.lang.Resume(), g.lang.Restart(), g.lang.BackToMenu()}
+	for i, o := range options {
+		if i == g.pauseIndex {
+			o = "> " + o + " <"
+		}
+		g.drawText(screen, o, 30, cx, float64(pauseOptionRect(i).Min.Y), text.AlignCenter, color.Black)
+	}
+	g.drawText(screen, g.lang.ResumeHint(), 14, cx, 360, text.AlignCenter, color.Black)
+}
@@ -0,0 +1,296 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"io/fs"
+	"log"
+	"path"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/rizalmf/old-boys/src/config"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/input"
+	"github.com/rizalmf/old-boys/src/replay"
+)
+
+const replaySeekStep = 5 * time.Second
+
+// runConfig adalah pengaturan run ini: milik replay saat diputar ulang,
+// selain itu milik pemain.
+func (g *MainScene) runConfig() config.Config {
+	if g.replaying {
+		return g.replay.Config
+	}
+	return g.config
+}
+
+func (g *MainScene) laneHeld(i int) *bool {
+	switch i {
+	case 0:
+		return &g.isNoteMan1Pressed
+	case 1:
+		return &g.isNoteMan3Pressed
+	}
+	return &g.isNoteMan2Pressed
+}
+
+// startRecording mulai merekam input untuk run baru, atau memutar replay
+// dari awal.
+func (g *MainScene) startRecording() {
+	for i := range g.lanes {
+		*g.laneHeld(i) = false
+	}
+	if g.replaying {
+		g.loadReplayScript(0)
+		return
+	}
+	g.recording = replay.New(g.chart.Hash, g.chart.Difficulty, g.config)
+}
+
+// saveRecording menyimpan rekaman run yang baru selesai sebagai replay
+// terakhir, dan sebagai replay skor terbaik jika run ini memecahkannya.
+func (g *MainScene) saveRecording() {
+	g.recording.Score = g.result.Score
+	g.recording.Accuracy = g.result.Accuracy
+	if err := g.recording.Save(replay.LastName); err != nil {
+		log.Printf("replay: save last run: %v", err)
+	}
+	if g.newBest {
+		if err := g.recording.Save(replay.BestName(g.scoreKey(g.runModifiers()))); err != nil {
+			log.Printf("replay: save personal best: %v", err)
+		}
+	}
+}
+
+// startReplay kembali ke layar judul lalu memutar replay seperti memulai
+// lagu biasa. Replay untuk chart lain ditolak.
+func (g *MainScene) startReplay(r *replay.Replay) {
+	if r.ChartHash != g.chart.Hash {
+		log.Printf("replay: recorded for chart %.16s, not %.16s", r.ChartHash, g.chart.Hash)
+		return
+	}
+	g.Reset()
+	g.replay = r
+	g.replaying = true
+	g.viewerPaused = false
+	g.viewerSpeed = 1
+	g.garageAnimActive = true
+	g.isVeryBegin = false
+}
+
+// loadLastReplay membuka replay terakhir dari folder data user.
+func (g *MainScene) loadLastReplay() {
+	r, err := replay.Load(replay.LastName)
+	if err != nil {
+		log.Printf("replay: load last run: %v", err)
+		return
+	}
+	g.startReplay(r)
+}
+
+// openDroppedReplay membuka file replay pertama yang di-drop ke jendela game.
+func (g *MainScene) openDroppedReplay(files fs.FS) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		log.Printf("replay: read dropped files: %v", err)
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != replay.Ext {
+			continue
+		}
+		r, err := replay.ReadFS(files, e.Name())
+		if err != nil {
+			log.Printf("replay: open %s: %v", e.Name(), err)
+			return
+		}
+		g.startReplay(r)
+		return
+	}
+}
+
+// loadReplayScript mengganti skrip input replay dengan bagian replay mulai
+// event from sampai Seek berikutnya.
+func (g *MainScene) loadReplayScript(from int) {
+	if g.replayScript != nil {
+		g.replayInput.Remove(g.replayScript)
+	}
+	g.replayScript, g.replaySeek = g.replay.Script(from)
+	g.replayInput.Add(g.replayScript)
+}
+
+// playReplayEvents memutar input replay sampai currentTick lewat replayInput
+// dan pressLane, kode yang sama dengan input pemain. Hasilnya true jika ada
+// event Seek yang memundurkan currentTick.
+func (g *MainScene) playReplayEvents() bool {
+	tick := g.currentTick
+	seek := g.replaySeek < len(g.replay.Events) && g.replay.Events[g.replaySeek].Tick <= tick
+	if seek {
+		tick = g.replay.Events[g.replaySeek].Tick
+	}
+	g.replayInput.Update(tick)
+	for i := range g.lanes {
+		*g.laneHeld(i) = g.replayInput.Held(input.Lane(i))
+	}
+	for _, e := range g.replayInput.Events() {
+		if lane, ok := e.Action.LaneIndex(); ok && e.Pressed {
+			g.advanceNotes(e.At)
+			g.pressLane(lane, e.At)
+		}
+	}
+	if !seek {
+		return false
+	}
+	g.advanceNotes(tick)
+	g.currentTick = g.replay.Events[g.replaySeek].To
+	g.loadReplayScript(g.replaySeek + 1)
+	return true
+}
+
+// endTick adalah posisi lagu saat layar hasil muncul.
+func (g *MainScene) endTick() float64 {
+	return g.lastTick + finishDelayTicks
+}
+
+// seekReplay memindahkan replay ke tick. Mundur berarti run diulang dari awal
+// lalu dijalankan cepat tanpa suara; hasilnya sama karena penilaian hanya
+// bergantung pada tick event.
+func (g *MainScene) seekReplay(tick float64) {
+	tick = min(max(tick, g.startTick), g.endTick())
+	if tick < g.currentTick {
+		g.resetRun()
+		g.startSong()
+	}
+	for !g.run.Failed {
+		g.currentTick = tick
+		if !g.playReplayEvents() {
+			break
+		}
+	}
+	g.advanceNotes(g.currentTick)
+	for _, p := range g.songPlayers() {
+		p.Pause()
+	}
+}
+
+// UpdateInGameReplay memutar replay dengan kontrol: Space untuk jeda,
+// kiri/kanan untuk mundur/maju 5 detik, 1/2/3 untuk 0.5x/1x/2x, Esc keluar.
+func (g *MainScene) UpdateInGameReplay() {
+	if g.attract && g.input.Any() {
+		g.Reset()
+		return
+	}
+	if g.run.Failed {
+		g.updateFail()
+		return
+	}
+	if g.input.JustPressed(input.Back) {
+		g.Reset()
+		return
+	}
+	if g.input.JustPressed(input.Confirm) {
+		g.viewerPaused = !g.viewerPaused
+	}
+	for a, speed := range map[input.Action]float64{input.HalfSpeed: 0.5, input.NormalSpeed: 1, input.DoubleSpeed: 2} {
+		if g.input.JustPressed(a) {
+			g.viewerSpeed = speed
+		}
+	}
+	seekStep := replaySeekStep.Seconds() * g.ticksPerSec * g.run.Rate
+	if g.input.JustPressed(input.Left) {
+		g.seekReplay(g.currentTick - seekStep)
+	}
+	if g.input.JustPressed(input.Right) {
+		g.seekReplay(g.currentTick + seekStep)
+	}
+
+	g.updateBand()
+
+	dt := time.Since(g.lastFrame).Seconds()
+	g.lastFrame = time.Now()
+
+	g.skyOffset -= 20 * dt
+	if g.skyOffset <= -constants.ScreenWidth {
+		g.skyOffset += constants.ScreenWidth
+	}
+
+	if !g.viewerPaused {
+		g.currentTick += g.ticksPerSec * dt * g.run.Rate * g.viewerSpeed
+		if g.playReplayEvents() {
+			g.syncStems()
+		}
+	}
+	g.advanceNotes(g.currentTick)
+
+	if g.endTick() < g.currentTick {
+		if g.attract {
+			g.Reset()
+			return
+		}
+		g.finishRun()
+		g.state = inGameFinish
+		return
+	}
+
+	// Suara hanya diputar saat replay jalan di 1x.
+	if g.viewerPaused || g.viewerSpeed != 1 {
+		for _, p := range g.songPlayers() {
+			p.Pause()
+		}
+	} else if !g.BassAudio.IsPlaying() && g.currentTick >= g.chart.AudioStart {
+		g.syncStems()
+	}
+	g.syncClick()
+}
+
+func (g *MainScene) DrawInGameReplay(screen *ebiten.Image) {
+	g.DrawInGamePlay(screen)
+	if g.attract {
+		g.drawText(screen, "Press any key", 18, constants.ScreenWidth/2, constants.ScreenHeight-30, text.AlignCenter, color.Black)
+		return
+	}
+
+	x, y, w := float32(15), float32(constants.ScreenHeight-14), float32(470)
+	progress := float32((g.currentTick - g.startTick) / (g.endTick() - g.startTick))
+	vector.DrawFilledRect(screen, x, y, w, 6, color.RGBA{24, 24, 24, 160}, false)
+	vector.DrawFilledRect(screen, x, y, w*min(max(progress, 0), 1), 6, lateColor, false)
+
+	status := fmt.Sprintf("PLAY %.1fx", g.viewerSpeed)
+	if g.viewerPaused {
+		status = "PAUSED"
+	}
+	g.drawText(screen, status+"    Space: pause   Left/Right: seek   1/2/3: speed   Esc: quit", 12, float64(x), float64(y-18), text.AlignStart, color.Black)
+}
+
+// drawReplayLabel menandai layar permainan saat replay atau autoplay diputar.
+func (g *MainScene) drawReplayLabel(screen *ebiten.Image) {
+	switch {
+	case g.attract:
+		g.drawText(screen, "DEMO", 20, 15, 10, text.AlignStart, lateColor)
+	case g.autoplay:
+		g.drawText(screen, "AUTOPLAY", 20, 15, 10, text.AlignStart, lateColor)
+	case g.replaying:
+		g.drawText(screen, "REPLAY", 20, 15, 10, text.AlignStart, lateColor)
+	}
+}
+
+// drawReplayResult mencocokkan skor replay dengan skor yang direkam.
+func (g *MainScene) drawReplayResult(screen *ebiten.Image) {
+	if !g.replaying {
+		g.drawText(screen, "R: Replay", 14, 20, 307, text.AlignStart, color.Black)
+		return
+	}
+	if g.autoplay {
+		g.drawAutoplayResult(screen)
+		return
+	}
+	texts, clr := fmt.Sprintf("REPLAY  recorded %d  OK", g.replay.Score), color.Color(color.RGBA{40, 160, 40, 255})
+	if g.replay.Score != g.result.Score {
+		texts, clr = fmt.Sprintf("REPLAY  recorded %d  MISMATCH", g.replay.Score), lateColor
+	}
+	g.drawText(screen, texts, 14, 20, 307, text.AlignStart, clr)
+}
@@ -0,0 +1,296 @@
+package scenes
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/input"
+	"github.com/rizalmf/old-boys/src/judge"
+	"github.com/rizalmf/old-boys/src/lang"
+	"github.com/rizalmf/old-boys/src/scoring"
+	"github.com/rizalmf/old-boys/src/sound"
+)
+
+// disabledColor adalah warna baris pengaturan yang tidak berlaku.
+var disabledColor = color.RGBA{140, 140, 140, 255}
+
+const (
+	settingsRowY   = 80
+	settingsRowGap = 22
+)
+
+// settingsButton adalah area tombol pengaturan di layar judul.
+var settingsButton = image.Rect(constants.ScreenWidth-150, constants.ScreenHeight-40, constants.ScreenWidth, constants.ScreenHeight)
+
+type settingItem struct {
+	label    func(l lang.Lang) string
+	value    func(g *MainScene) string
+	change   func(g *MainScene, dir int)
+	disabled func(g *MainScene) bool // Boleh nil. Baris abu-abu dan tidak bisa diubah.
+}
+
+func (s settingItem) isDisabled(g *MainScene) bool {
+	return s.disabled != nil && s.disabled(g)
+}
+
+func (g *MainScene) settingItems() []settingItem {
+	return []settingItem{
+		{
+			label: lang.Lang.SkipIntro,
+			value: func(g *MainScene) string { return g.onOff(g.config.SkipIntro) },
+			change: func(g *MainScene, dir int) {
+				g.config.SkipIntro = !g.config.SkipIntro
+			},
+		},
+		{
+			label: lang.Lang.Metronome,
+			value: func(g *MainScene) string {
+				if !g.chart.HasTempo() {
+					return g.lang.NoTempo()
+				}
+				return percent(g.config.MetronomeVolume)
+			},
+			change: func(g *MainScene, dir int) {
+				g.config.MetronomeVolume = stepVolume(g.config.MetronomeVolume, dir)
+				g.MetronomeAudio.SetVolume(g.config.MetronomeVolume)
+			},
+			// Tanpa tempo di chart, metronom dan beat pulse tidak berbunyi.
+			disabled: func(g *MainScene) bool { return !g.chart.HasTempo() },
+		},
+		{
+			label: lang.Lang.SongRate,
+			value: func(g *MainScene) string { return fmt.Sprintf("%.1fx", g.config.SongRate) },
+			change: func(g *MainScene, dir int) {
+				g.config.SongRate = stepRate(g.config.SongRate, dir)
+			},
+		},
+		{
+			label: lang.Lang.Judgement,
+			value: func(g *MainScene) string { return g.config.Judgement.String() },
+			change: func(g *MainScene, dir int) {
+				g.config.Judgement = (g.config.Judgement + judge.PresetCount + judge.Preset(dir)) % judge.PresetCount
+			},
+		},
+		{
+			label: lang.Lang.ComboMultiplier,
+			value: func(g *MainScene) string { return g.onOff(g.config.ComboMultiplier) },
+			change: func(g *MainScene, dir int) {
+				g.config.ComboMultiplier = !g.config.ComboMultiplier
+			},
+		},
+		{
+			label: lang.Lang.NoFail,
+			value: func(g *MainScene) string { return g.onOff(g.config.NoFail) },
+			change: func(g *MainScene, dir int) {
+				g.config.NoFail = !g.config.NoFail
+			},
+		},
+		{
+			label: lang.Lang.GhostTap,
+			value: func(g *MainScene) string { return g.onOff(g.config.GhostTapPenalty) },
+			change: func(g *MainScene, dir int) {
+				g.config.GhostTapPenalty = !g.config.GhostTapPenalty
+			},
+		},
+		{
+			label: lang.Lang.Scoring,
+			value: func(g *MainScene) string { return scoring.New(g.config.Scoring, scoring.Options{}).Name() },
+			change: func(g *MainScene, dir int) {
+				i := slices.Index(scoring.Names, g.config.Scoring)
+				g.config.Scoring = scoring.Names[(max(i, 0)+len(scoring.Names)+dir)%len(scoring.Names)]
+			},
+		},
+		{
+			label: lang.Lang.Ghost,
+			value: func(g *MainScene) string { return g.onOff(g.config.Ghost) },
+			change: func(g *MainScene, dir int) {
+				g.config.Ghost = !g.config.Ghost
+			},
+		},
+		{
+			label: lang.Lang.AutoplayJitter,
+			value: func(g *MainScene) string {
+				if g.config.AutoplayJitter == 0 {
+					return g.lang.Off()
+				}
+				return fmt.Sprintf("%.0f ms", g.config.AutoplayJitter)
+			},
+			change: func(g *MainScene, dir int) {
+				g.config.AutoplayJitter = stepJitter(g.config.AutoplayJitter, dir)
+			},
+		},
+		{
+			label: lang.Lang.KeyBindings,
+			value: func(g *MainScene) string {
+				var keys []string
+				for i := range g.lanes {
+					keys = append(keys, keyLabel(g.keyName(i, 0)))
+				}
+				return strings.Join(keys, " ")
+			},
+			change: func(g *MainScene, dir int) {
+				g.openKeyBindings()
+			},
+		},
+		{
+			label: lang.Lang.TouchLayout,
+			value: func(g *MainScene) string { return touchLayoutName(g.config.TouchLayout) },
+			change: func(g *MainScene, dir int) {
+				i := slices.Index(touchLayouts, g.config.TouchLayout)
+				g.config.TouchLayout = touchLayouts[(max(i, 0)+len(touchLayouts)+dir)%len(touchLayouts)]
+				g.setTouchZones()
+			},
+		},
+		{
+			label: lang.Lang.TouchZones,
+			value: func(g *MainScene) string { return g.onOff(g.config.TouchZones) },
+			change: func(g *MainScene, dir int) {
+				g.config.TouchZones = !g.config.TouchZones
+			},
+		},
+	}
+}
+
+// stepVolume menaikkan/menurunkan volume 10% dan berputar di ujungnya.
+func stepVolume(v float64, dir int) float64 {
+	step := int(math.Round(v*10)) + dir
+	if step > 10 {
+		step = 0
+	} else if step < 0 {
+		step = 10
+	}
+	return float64(step) / 10
+}
+
+// stepRate mengubah kecepatan lagu 0.1x dalam rentang sound.MinRate-MaxRate.
+func stepRate(v float64, dir int) float64 {
+	step := int(math.Round(v*10)) + dir
+	if step > int(sound.MaxRate*10) {
+		step = int(sound.MinRate * 10)
+	} else if step < int(sound.MinRate*10) {
+		step = int(sound.MaxRate * 10)
+	}
+	return float64(step) / 10
+}
+
+func percent(v float64) string {
+	return fmt.Sprintf("%d%%", int(math.Round(v*100)))
+}
+
+func (g *MainScene) onOff(v bool) string {
+	if v {
+		return g.lang.On()
+	}
+	return g.lang.Off()
+}
+
+// settingsRequested cek apakah pemain membuka pengaturan dari layar judul.
+func (g *MainScene) settingsRequested() bool {
+	if g.input.JustPressed(input.Settings) {
+		return true
+	}
+	for _, pt := range g.input.Taps() {
+		if pt.In(settingsButton) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *MainScene) openSettings() {
+	g.state = inGameSettings
+	g.settingsIndex = 0
+}
+
+func (g *MainScene) closeSettings() {
+	if err := g.config.Save(); err != nil {
+		log.Printf("settings: save config: %v", err)
+	}
+	g.state = inGameMenu
+}
+
+func settingsRowRect(i int) image.Rectangle {
+	y := settingsRowY + settingsRowGap*i
+	return image.Rect(constants.ScreenWidth/2-220, y, constants.ScreenWidth/2+220, y+settingsRowGap)
+}
+
+func (g *MainScene) UpdateInGameSettings() {
+	items := g.settingItems()
+	rows := len(items) + 1 // + Back
+
+	if g.input.JustPressed(input.Back) {
+		g.closeSettings()
+		return
+	}
+	if g.input.JustPressed(input.Up) {
+		g.settingsIndex = (g.settingsIndex + rows - 1) % rows
+	}
+	if g.input.JustPressed(input.Down) {
+		g.settingsIndex = (g.settingsIndex + 1) % rows
+	}
+
+	dir := 0
+	switch {
+	case g.input.JustPressed(input.Left):
+		dir = -1
+	case g.input.JustPressed(input.Right), g.input.JustPressed(input.Confirm):
+		dir = 1
+	}
+
+	for _, pt := range g.input.Taps() {
+		for i := range rows {
+			if pt.In(settingsRowRect(i)) {
+				g.settingsIndex = i
+				dir = 1
+			}
+		}
+	}
+
+	if dir == 0 {
+		return
+	}
+	if g.settingsIndex == len(items) {
+		g.closeSettings()
+		return
+	}
+	if item := items[g.settingsIndex]; !item.isDisabled(g) {
+		item.change(g, dir)
+	}
+}
+
+func (g *MainScene) DrawInGameSettings(screen *ebiten.Image) {
+	g.DrawInGameMenu(screen)
+
+	vector.DrawFilledRect(screen, 0, 0, constants.ScreenWidth, constants.ScreenHeight, color.RGBA{255, 255, 255, 200}, false)
+
+	cx := float64(constants.ScreenWidth / 2)
+	g.drawText(screen, g.lang.Settings(), 48, cx, 40, text.AlignCenter, color.Black)
+
+	items := g.settingItems()
+	for i, item := range items {
+		texts := fmt.Sprintf("%s:  %s", item.label(g.lang), item.value(g))
+		if i == g.settingsIndex {
+			texts = "> " + texts + " <"
+		}
+		clr := color.Color(color.Black)
+		if item.isDisabled(g) {
+			clr = disabledColor
+		}
+		g.drawText(screen, texts, 22, cx, float64(settingsRowRect(i).Min.Y), text.AlignCenter, clr)
+	}
+
+	back := g.lang.Back()
+	if g.settingsIndex == len(items) {
+		back = "> " + back + " <"
+	}
+	g.drawText(screen, back, 22, cx, float64(settingsRowRect(len(items)).Min.Y), text.AlignCenter, color.Black)
+}
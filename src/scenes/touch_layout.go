@@ -0,0 +1,91 @@
+package scenes
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/rizalmf/old-boys/src/constants"
+	"github.com/rizalmf/old-boys/src/input"
+	"github.com/rizalmf/old-boys/src/input/device"
+)
+
+// Tata letak area sentuh lajur, disimpan di config.TouchLayout.
+const (
+	touchIcons = "icons" // Kotak kecil di bawah ikon lajur.
+	touchLanes = "lanes" // Seluruh kolom lajur di highway sampai bawah layar.
+	touchPads  = "pads"  // Pad besar berjajar di bawah layar.
+	touchSplit = "split" // Layar di bawah strip atas dibagi rata menjadi kolom per lajur.
+)
+
+var touchLayouts = []string{touchIcons, touchLanes, touchPads, touchSplit}
+
+const (
+	touchPadHeight = 110
+	touchPadGap    = 6
+	touchSplitTop  = 60 // Strip atas tanpa lajur, untuk tombol pause.
+)
+
+func touchLayoutName(layout string) string {
+	switch layout {
+	case touchLanes:
+		return "Full Lane"
+	case touchPads:
+		return "Bottom Pads"
+	case touchSplit:
+		return "Split Screen"
+	}
+	return "Icons"
+}
+
+// touchZone menghitung area sentuh lajur i dari n lajur berdasarkan posisi
+// highway dan layout.
+func touchZone(layout string, i, n int) image.Rectangle {
+	x := firstNoteX + noteLineWidth*i
+	w := constants.ScreenWidth / n
+	switch layout {
+	case touchLanes:
+		return image.Rect(x, NoteY, x+noteLineWidth, constants.ScreenHeight)
+	case touchPads:
+		return image.Rect(w*i+touchPadGap/2, constants.ScreenHeight-touchPadHeight, w*(i+1)-touchPadGap/2, constants.ScreenHeight)
+	case touchSplit:
+		return image.Rect(w*i, touchSplitTop, w*(i+1), constants.ScreenHeight)
+	}
+	y := NoteY + NoteHeight
+	return image.Rect(x-5, y+3, x+noteLineWidth-4, y+29)
+}
+
+// setTouchZones menghitung TouchRange setiap lajur dari layout di config dan
+// memasangnya sebagai zona mouse dan sentuhan.
+func (g *MainScene) setTouchZones() {
+	zones := make([]device.Zone, len(g.lanes))
+	for i := range g.lanes {
+		g.lanes[i].TouchRange = touchZone(g.config.TouchLayout, i, len(g.lanes))
+		zones[i] = device.Zone{Rect: g.lanes[i].TouchRange, Action: input.Lane(i)}
+	}
+	g.mouse.SetZones(zones)
+	g.touch.SetZones(zones)
+}
+
+// drawTouchZones menggambar area sentuh setiap lajur jika config.TouchZones
+// aktif. Area yang sedang ditahan digambar lebih tebal.
+func (g *MainScene) drawTouchZones(screen *ebiten.Image) {
+	if !g.config.TouchZones {
+		return
+	}
+	for i, lane := range g.lanes {
+		r := lane.TouchRange
+		alpha := uint8(50)
+		if g.input.Held(input.Lane(i)) {
+			alpha = 120
+		}
+		fill := color.NRGBAModel.Convert(lane.Color).(color.NRGBA)
+		fill.A = alpha
+		x, y, w, h := float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy())
+		vector.DrawFilledRect(screen, x, y, w, h, fill, false)
+		vector.StrokeRect(screen, x, y, w, h, 1, color.Black, false)
+		g.drawText(screen, g.lang.LaneName(i), 12, float64(r.Min.X+r.Dx()/2), float64(r.Min.Y+2), text.AlignCenter, color.Black)
+	}
+}
@@ -0,0 +1,144 @@
+// Package scores menyimpan skor terbaik pemain per chart, tingkat
+// kesulitan dan modifier di folder data user.
+package scores
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rizalmf/old-boys/src/config"
+	"github.com/rizalmf/old-boys/src/judge"
+)
+
+const fileName = "scores.json"
+
+// Modifiers adalah pengaturan yang mempengaruhi skor sebuah run.
+type Modifiers struct {
+	Rule     string  // Nama aturan skor.
+	Preset   string  // Preset window penilaian.
+	Rate     float64 // Kecepatan lagu.
+	NoFail   bool
+	GhostTap bool
+}
+
+// Record adalah hasil satu run.
+type Record struct {
+	Score     int
+	Accuracy  float64
+	Grade     string
+	Counts    [judge.JudgementCount]int
+	MaxCombo  int
+	Modifiers Modifiers
+	Date      time.Time
+}
+
+// Beats benar jika r lebih baik dari prev. Keduanya punya kunci yang sama,
+// jadi aturan skornya sama dan cukup skornya yang dibandingkan.
+func (r Record) Beats(prev Record) bool {
+	return r.Score > prev.Score
+}
+
+// Key menggabungkan hash chart, tingkat kesulitan dan semua modifier yang
+// mempengaruhi skor (aturan skor, preset penilaian, kecepatan, ghost tap,
+// no-fail) menjadi kunci skor. Run dengan modifier berbeda punya skor
+// terbaik sendiri.
+func Key(chartHash, difficulty string, m Modifiers) string {
+	k := fmt.Sprintf("%s/%s/%s/%s/x%.1f", chartHash, difficulty, m.Rule, m.Preset, m.Rate)
+	if m.GhostTap {
+		k += "/ghosttap"
+	}
+	if m.NoFail {
+		k += "/nofail"
+	}
+	return k
+}
+
+type Store struct {
+	path string
+	Best map[string]Record
+}
+
+// Load membaca skor dari folder data user. File yang rusak disimpan sebagai
+// .bak lalu diganti store kosong, jadi satu file rusak tidak menghentikan game.
+// Jika file tidak bisa dibaca atau dipindahkan, store yang dikembalikan tidak
+// punya path dan Save menolak menimpa file itu.
+func Load() (*Store, error) {
+	s := &Store{Best: map[string]Record{}}
+	dir, err := config.Dir()
+	if err != nil {
+		return s, err
+	}
+	path := filepath.Join(dir, fileName)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.path = path
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("scores: read %s, not saving this session: %w", fileName, err)
+	}
+	if err := json.Unmarshal(data, &s.Best); err != nil || s.Best == nil {
+		s.Best = map[string]Record{}
+		if rerr := os.Rename(path, path+".bak"); rerr != nil {
+			return s, fmt.Errorf("scores: %s is corrupt and could not be moved, not saving this session: %w", fileName, rerr)
+		}
+		s.path = path
+		return s, fmt.Errorf("scores: %s is corrupt, moved to %s.bak: %v", fileName, fileName, err)
+	}
+	s.path = path
+	return s, nil
+}
+
+// Get mengembalikan skor terbaik untuk key.
+func (s *Store) Get(key string) (Record, bool) {
+	r, ok := s.Best[key]
+	return r, ok
+}
+
+// Submit mencatat run dan mengembalikan true jika run ini skor terbaik baru.
+func (s *Store) Submit(key string, r Record) bool {
+	prev, ok := s.Best[key]
+	if ok && !r.Beats(prev) {
+		return false
+	}
+	s.Best[key] = r
+	return true
+}
+
+// Save menulis skor secara atomik: tulis ke file sementara lalu rename,
+// jadi file lama tetap utuh jika game berhenti di tengah penulisan.
+func (s *Store) Save() error {
+	if s.path == "" {
+		return errors.New("scores: no writable score file")
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s.Best, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), fileName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
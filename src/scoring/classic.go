@@ -0,0 +1,59 @@
+package scoring
+
+import "github.com/rizalmf/old-boys/src/judge"
+
+const (
+	comboTierNotes = 10 // Pengali naik satu tingkat setiap kelipatan ini.
+	maxMultiplier  = 4
+)
+
+// classicPoints adalah nilai satu not untuk setiap tingkat penilaian.
+var classicPoints = [judge.JudgementCount]int{
+	judge.Perfect: 100,
+	judge.Great:   75,
+	judge.Good:    50,
+	judge.Bad:     20,
+	judge.Miss:    0, // tanpa penalty
+}
+
+// classic adalah aturan bawaan: poin per not dikali pengali combo x1-x4.
+type classic struct {
+	tally
+	multiplier bool
+	combo      int
+	score      int
+}
+
+func NewClassic(opts Options) Rule {
+	return &classic{tally: tally{grades: opts.Grades}, multiplier: opts.Multiplier}
+}
+
+func (c *classic) Name() string {
+	return "Classic"
+}
+
+func (c *classic) Judge(e Event) {
+	c.add(e.Judgement)
+	c.combo = e.Combo
+	c.score += classicPoints[e.Judgement] * c.Multiplier()
+}
+
+// Multiplier bernilai x1 sampai x4 sesuai panjang combo saat ini.
+func (c *classic) Multiplier() int {
+	if !c.multiplier {
+		return 1
+	}
+	return min(1+c.combo/comboTierNotes, maxMultiplier)
+}
+
+func (c *classic) Score() int {
+	return c.score
+}
+
+func (c *classic) Accuracy() float64 {
+	return judge.Accuracy(c.counts)
+}
+
+func (c *classic) Grade() judge.Grade {
+	return c.grades.Grade(c.Accuracy())
+}
@@ -0,0 +1,37 @@
+package scoring
+
+import "github.com/rizalmf/old-boys/src/judge"
+
+// ex menghitung EX score: 2 poin untuk perfect, 1 untuk great, selain itu 0.
+// Akurasinya adalah EX score dibagi nilai maksimum not yang sudah dinilai.
+type ex struct {
+	tally
+}
+
+func NewEX(opts Options) Rule {
+	return &ex{tally: tally{grades: opts.Grades}}
+}
+
+func (x *ex) Name() string {
+	return "EX"
+}
+
+func (x *ex) Judge(e Event) {
+	x.add(e.Judgement)
+}
+
+func (x *ex) Score() int {
+	return 2*x.counts[judge.Perfect] + x.counts[judge.Great]
+}
+
+func (x *ex) Accuracy() float64 {
+	n := x.judged()
+	if n == 0 {
+		return 0
+	}
+	return float64(x.Score()) / float64(2*n) * 100
+}
+
+func (x *ex) Grade() judge.Grade {
+	return x.grades.Grade(x.Accuracy())
+}
@@ -0,0 +1,44 @@
+package scoring
+
+import (
+	"math"
+
+	"github.com/rizalmf/old-boys/src/judge"
+)
+
+const millionMax = 1_000_000
+
+// million membagi 1.000.000 poin rata ke semua not chart, dikali bobot
+// akurasi penilaiannya. Skor bisa dibandingkan antar chart beda panjang.
+type million struct {
+	tally
+	total int
+}
+
+func NewMillion(opts Options) Rule {
+	return &million{tally: tally{grades: opts.Grades}, total: opts.TotalNotes}
+}
+
+func (m *million) Name() string {
+	return "1,000,000"
+}
+
+func (m *million) Judge(e Event) {
+	m.add(e.Judgement)
+}
+
+func (m *million) Score() int {
+	if m.total == 0 {
+		return 0
+	}
+	weighted := judge.Accuracy(m.counts) / 100 * float64(m.judged())
+	return int(math.Round(weighted / float64(m.total) * millionMax))
+}
+
+func (m *million) Accuracy() float64 {
+	return judge.Accuracy(m.counts)
+}
+
+func (m *million) Grade() judge.Grade {
+	return m.grades.Grade(m.Accuracy())
+}
@@ -0,0 +1,73 @@
+// Package scoring berisi aturan skor yang bisa dipilih per chart atau per
+// pemain. Setiap aturan menerima event penilaian dan melaporkan skor,
+// akurasi serta grade.
+package scoring
+
+import "github.com/rizalmf/old-boys/src/judge"
+
+// Event adalah satu not yang sudah dinilai.
+type Event struct {
+	Judgement judge.Judgement
+	OffsetMs  float64 // Selisih waktu tekan; 0 untuk miss.
+	Combo     int     // Combo setelah not ini dinilai.
+}
+
+type Rule interface {
+	Name() string
+	Judge(e Event)
+	Score() int
+	Accuracy() float64 // 0 sampai 100.
+	Grade() judge.Grade
+}
+
+// Multiplier diimplementasikan aturan yang punya pengali combo.
+type Multiplier interface {
+	Multiplier() int
+}
+
+type Options struct {
+	TotalNotes int              // Jumlah not dalam chart.
+	Multiplier bool             // Pengali combo (hanya aturan classic).
+	Grades     judge.Thresholds // Batas akurasi setiap grade.
+}
+
+const (
+	Classic = "classic"
+	EX      = "ex"
+	Million = "million"
+)
+
+// Names adalah semua aturan yang tersedia, urut untuk pilihan di pengaturan.
+var Names = []string{Classic, EX, Million}
+
+var rules = map[string]func(Options) Rule{
+	Classic: NewClassic,
+	EX:      NewEX,
+	Million: NewMillion,
+}
+
+// New membuat aturan berdasarkan nama. Nama yang tidak dikenal memakai classic.
+func New(name string, opts Options) Rule {
+	if f, ok := rules[name]; ok {
+		return f(opts)
+	}
+	return NewClassic(opts)
+}
+
+// tally mencatat jumlah setiap penilaian, dipakai bersama oleh semua aturan.
+type tally struct {
+	counts [judge.JudgementCount]int
+	grades judge.Thresholds
+}
+
+func (t *tally) add(j judge.Judgement) {
+	t.counts[j]++
+}
+
+func (t *tally) judged() int {
+	n := 0
+	for _, c := range t.counts {
+		n += c
+	}
+	return n
+}
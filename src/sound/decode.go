@@ -0,0 +1,120 @@
+package sound
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2/audio/mp3"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+	"github.com/rizalmf/old-boys/assets/sounds"
+)
+
+const (
+	channelNum     = 2
+	bytesPerSample = 4 // float32
+	bytesPerFrame  = channelNum * bytesPerSample
+)
+
+type Format int8
+
+const (
+	FormatUnknown Format = iota
+	FormatMP3
+	FormatOgg
+	FormatWAV
+	FormatFLAC
+)
+
+// Stream is a decoded stem in 32bit float, little endian, 2 channels (stereo)
+// format, already resampled to sounds.Rates.
+type Stream struct {
+	io.ReadSeeker
+	length int64
+}
+
+// Length returns the size of the decoded stream in bytes.
+func (s *Stream) Length() int64 {
+	return s.length
+}
+
+// DetectFormat looks at the magic bytes of src first, and falls back to the
+// extension of name when the header is not recognized.
+func DetectFormat(name string, src []byte) Format {
+	switch {
+	case bytes.HasPrefix(src, []byte("OggS")):
+		return FormatOgg
+	case bytes.HasPrefix(src, []byte("fLaC")):
+		return FormatFLAC
+	case len(src) >= 12 && bytes.Equal(src[0:4], []byte("RIFF")) && bytes.Equal(src[8:12], []byte("WAVE")):
+		return FormatWAV
+	case bytes.HasPrefix(src, []byte("ID3")):
+		return FormatMP3
+	case len(src) >= 2 && src[0] == 0xff && src[1]&0xe0 == 0xe0: // frame sync
+		return FormatMP3
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".mp3":
+		return FormatMP3
+	case ".ogg", ".oga":
+		return FormatOgg
+	case ".wav", ".wave":
+		return FormatWAV
+	case ".flac":
+		return FormatFLAC
+	}
+	return FormatUnknown
+}
+
+// Decode decodes an MP3, Ogg Vorbis, WAV or FLAC stem and resamples it to
+// sounds.Rates when the source uses another sample rate.
+func Decode(name string, src []byte) (*Stream, error) {
+	var (
+		r      io.ReadSeeker
+		length int64
+		rate   int
+	)
+
+	switch DetectFormat(name, src) {
+	case FormatMP3:
+		s, err := mp3.DecodeF32(bytes.NewReader(src))
+		if err != nil {
+			return nil, fmt.Errorf("sound: decode %s: %w", name, err)
+		}
+		r, length, rate = s, s.Length(), s.SampleRate()
+	case FormatOgg:
+		s, err := vorbis.DecodeF32(bytes.NewReader(src))
+		if err != nil {
+			return nil, fmt.Errorf("sound: decode %s: %w", name, err)
+		}
+		r, length, rate = s, s.Length(), s.SampleRate()
+	case FormatWAV:
+		s, err := wav.DecodeF32(bytes.NewReader(src))
+		if err != nil {
+			return nil, fmt.Errorf("sound: decode %s: %w", name, err)
+		}
+		r, length, rate = s, s.Length(), s.SampleRate()
+	case FormatFLAC:
+		s, err := newFlacStream(bytes.NewReader(src))
+		if err != nil {
+			return nil, fmt.Errorf("sound: decode %s: %w", name, err)
+		}
+		r, length, rate = s, s.Length(), s.SampleRate()
+	default:
+		return nil, fmt.Errorf("sound: unknown format of %s", name)
+	}
+
+	if rate != sounds.Rates {
+		rs := newResampler(r, length, rate, sounds.Rates)
+		r, length = rs, rs.Length()
+	}
+
+	return &Stream{
+		ReadSeeker: r,
+		length:     length,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package sound
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/rizalmf/old-boys/assets/sounds"
+)
+
+func TestDetectFormat(t *testing.T) {
+	riff := []byte("RIFF\x24\x00\x00\x00WAVEfmt ")
+	tests := []struct {
+		name string
+		src  []byte
+		want Format
+	}{
+		{"a.ogg", []byte("OggS\x00\x02"), FormatOgg},
+		{"a.flac", []byte("fLaC\x00\x00\x00\x22"), FormatFLAC},
+		{"a.wav", riff, FormatWAV},
+		{"a.mp3", []byte("ID3\x04\x00"), FormatMP3},
+		{"a.mp3", []byte{0xff, 0xfb, 0x90, 0x64}, FormatMP3},
+
+		// Magic bytes menang atas ekstensi yang salah.
+		{"stem.mp3", []byte("OggS\x00\x02"), FormatOgg},
+		{"stem.ogg", []byte("fLaC"), FormatFLAC},
+		{"stem.flac", riff, FormatWAV},
+		{"stem", []byte("ID3"), FormatMP3},
+
+		// Header tidak dikenal: pakai ekstensi, tanpa peduli huruf besar.
+		{"stem.MP3", []byte("????"), FormatMP3},
+		{"stem.oga", nil, FormatOgg},
+		{"stem.Wave", []byte("x"), FormatWAV},
+		{"dir.v2/stem.FLAC", []byte("x"), FormatFLAC},
+		{"stem.aac", []byte("x"), FormatUnknown},
+		{"stem", nil, FormatUnknown},
+		{"short.bin", []byte("RIFF"), FormatUnknown},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat(tt.name, tt.src); got != tt.want {
+			t.Errorf("DetectFormat(%q, %q) = %v, want %v", tt.name, tt.src, got, tt.want)
+		}
+	}
+}
+
+// wav16 membuat file WAV PCM 16bit stereo dengan sampel konstan v.
+func wav16(rate, frames int, v int16) []byte {
+	data := frames * 4
+	b := []byte("RIFF")
+	b = binary.LittleEndian.AppendUint32(b, uint32(36+data))
+	b = append(b, "WAVEfmt "...)
+	b = binary.LittleEndian.AppendUint32(b, 16)
+	b = binary.LittleEndian.AppendUint16(b, 1) // PCM
+	b = binary.LittleEndian.AppendUint16(b, 2)
+	b = binary.LittleEndian.AppendUint32(b, uint32(rate))
+	b = binary.LittleEndian.AppendUint32(b, uint32(rate*4))
+	b = binary.LittleEndian.AppendUint16(b, 4)
+	b = binary.LittleEndian.AppendUint16(b, 16)
+	b = append(b, "data"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(data))
+	for range frames * 2 {
+		b = binary.LittleEndian.AppendUint16(b, uint16(v))
+	}
+	return b
+}
+
+func TestDecodeResamplesToRates(t *testing.T) {
+	for _, rate := range []int{22050, sounds.Rates, 48000, 96000} {
+		frames := rate / 2 // Setengah detik.
+		s, err := Decode("stem", wav16(rate, frames, 1<<14))
+		if err != nil {
+			t.Fatalf("%d Hz: %v", rate, err)
+		}
+		want := int64(frames) * int64(sounds.Rates) / int64(rate) * bytesPerFrame
+		if s.Length() != want {
+			t.Errorf("%d Hz: Length = %d, want %d", rate, s.Length(), want)
+		}
+		out, err := io.ReadAll(s)
+		if err != nil {
+			t.Fatalf("%d Hz: %v", rate, err)
+		}
+		if int64(len(out)) != want {
+			t.Errorf("%d Hz: read %d bytes, want %d", rate, len(out), want)
+		}
+		for i, v := range samples(t, out) {
+			if math.Abs(float64(v)-0.5) > 1e-3 {
+				t.Errorf("%d Hz: frame %d = %v, want 0.5", rate, i, v)
+				break
+			}
+		}
+	}
+}
+
+func TestDecodeUnknown(t *testing.T) {
+	if _, err := Decode("stem.aac", []byte("not audio")); err == nil {
+		t.Error("Decode of an unknown format did not fail")
+	}
+}
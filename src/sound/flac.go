@@ -0,0 +1,125 @@
+//go:build !js
+
+package sound
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/mewkiz/flac"
+)
+
+// flacStream decodes FLAC frames on demand into 32bit float stereo bytes.
+type flacStream struct {
+	stream   *flac.Stream
+	channels int
+	scale    float32
+	frames   int64
+	pos      int64     // frame
+	pending  []float32 // decoded but unread samples, interleaved stereo
+}
+
+func newFlacStream(src io.ReadSeeker) (*flacStream, error) {
+	s, err := flac.NewSeek(src)
+	if err != nil {
+		return nil, err
+	}
+	channels := int(s.Info.NChannels)
+	if channels != 1 && channels != 2 {
+		return nil, fmt.Errorf("flac: number of channels must be 1 or 2 but was %d", channels)
+	}
+
+	return &flacStream{
+		stream:   s,
+		channels: channels,
+		scale:    1 / float32(int64(1)<<(s.Info.BitsPerSample-1)),
+		frames:   int64(s.Info.NSamples),
+	}, nil
+}
+
+func (s *flacStream) SampleRate() int {
+	return int(s.stream.Info.SampleRate)
+}
+
+func (s *flacStream) Length() int64 {
+	return s.frames * bytesPerFrame
+}
+
+func (s *flacStream) Read(p []byte) (int, error) {
+	n := 0
+	for n+bytesPerFrame <= len(p) {
+		if len(s.pending) == 0 {
+			if err := s.decodeNext(); err != nil {
+				if err == io.EOF && n > 0 {
+					return n, nil
+				}
+				return n, err
+			}
+		}
+		for ch := 0; ch < channelNum; ch++ {
+			binary.LittleEndian.PutUint32(p[n+ch*bytesPerSample:], math.Float32bits(s.pending[ch]))
+		}
+		s.pending = s.pending[channelNum:]
+		n += bytesPerFrame
+		s.pos++
+	}
+	return n, nil
+}
+
+func (s *flacStream) decodeNext() error {
+	f, err := s.stream.ParseNext()
+	if err != nil {
+		return err
+	}
+
+	left := f.Subframes[0].Samples
+	right := left
+	if s.channels == 2 {
+		right = f.Subframes[1].Samples
+	}
+	s.pending = s.pending[:0]
+	for i := range left {
+		s.pending = append(s.pending, float32(left[i])*s.scale, float32(right[i])*s.scale)
+	}
+	return nil
+}
+
+func (s *flacStream) Seek(offset int64, whence int) (int64, error) {
+	var next int64
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = s.pos*bytesPerFrame + offset
+	case io.SeekEnd:
+		next = s.Length() + offset
+	default:
+		return 0, errors.New("flac: invalid whence")
+	}
+	if next < 0 {
+		return 0, errors.New("flac: negative position")
+	}
+
+	target := next / bytesPerFrame
+	s.pending = s.pending[:0]
+	if target >= s.frames {
+		s.pos = s.frames
+		return s.pos * bytesPerFrame, nil
+	}
+
+	// Seek lands on the start of the frame containing target; decode it and
+	// drop the leading samples so the position is exact.
+	start, err := s.stream.Seek(uint64(target))
+	if err != nil {
+		return 0, err
+	}
+	if err := s.decodeNext(); err != nil {
+		return 0, err
+	}
+	s.pending = s.pending[int(target-int64(start))*channelNum:]
+	s.pos = target
+	return s.pos * bytesPerFrame, nil
+}
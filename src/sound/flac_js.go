@@ -0,0 +1,27 @@
+//go:build js
+
+package sound
+
+import (
+	"errors"
+	"io"
+)
+
+// The FLAC decoder depends on a terminal package that does not build for
+// js/wasm, so the web build reports FLAC stems as unsupported. The embedded
+// stems are MP3.
+type flacStream struct {
+	io.ReadSeeker
+}
+
+func newFlacStream(src io.ReadSeeker) (*flacStream, error) {
+	return nil, errors.New("flac: not supported in the web build")
+}
+
+func (s *flacStream) SampleRate() int {
+	return 0
+}
+
+func (s *flacStream) Length() int64 {
+	return 0
+}
@@ -0,0 +1,131 @@
+package sound
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/rizalmf/old-boys/assets/sounds"
+)
+
+const (
+	clickFreq     = 1200.0 // Hz
+	clickDuration = 0.03   // seconds
+	clickDecay    = 0.006  // seconds
+)
+
+// ClickTrack is a generated stream in the same format as Stream with a short
+// click on every beat. Beats are given as frame positions, so the clicks are
+// placed on exact samples of the stem timeline rather than on game frames.
+type ClickTrack struct {
+	m sync.Mutex
+
+	source      []int64 // beat frames at rate 1
+	beats       []int64 // beat frames at the current rate
+	frames      int64
+	clickFrames int64
+	pos         int64 // frame
+}
+
+// NewClickTrack creates a click track. beats are frame positions at
+// sounds.Rates, measured from the start of the stems.
+func NewClickTrack(beats []int64) *ClickTrack {
+	b := make([]int64, 0, len(beats))
+	for _, f := range beats {
+		if f >= 0 {
+			b = append(b, f)
+		}
+	}
+	sort.Slice(b, func(i, j int) bool { return b[i] < b[j] })
+
+	c := &ClickTrack{
+		source:      b,
+		clickFrames: int64(clickDuration * float64(sounds.Rates)),
+	}
+	c.setRate(1)
+	return c
+}
+
+// SetRate moves the beats to match stems stretched by TimeStretch at the same
+// rate. The click itself keeps its pitch and length.
+func (c *ClickTrack) SetRate(rate float64) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.setRate(math.Min(math.Max(rate, MinRate), MaxRate))
+}
+
+func (c *ClickTrack) setRate(rate float64) {
+	c.beats = c.beats[:0]
+	for _, f := range c.source {
+		c.beats = append(c.beats, int64(float64(f)/rate))
+	}
+	c.frames = 0
+	if len(c.beats) > 0 {
+		c.frames = c.beats[len(c.beats)-1] + c.clickFrames
+	}
+}
+
+// Length returns the size of the click track in bytes.
+func (c *ClickTrack) Length() int64 {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.frames * bytesPerFrame
+}
+
+func (c *ClickTrack) Read(p []byte) (int, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if c.pos >= c.frames {
+		return 0, io.EOF
+	}
+
+	// Index of the last beat at or before pos.
+	i := sort.Search(len(c.beats), func(i int) bool { return c.beats[i] > c.pos }) - 1
+
+	n := 0
+	for n+bytesPerFrame <= len(p) && c.pos < c.frames {
+		for i+1 < len(c.beats) && c.beats[i+1] <= c.pos {
+			i++
+		}
+
+		var v float32
+		if i >= 0 && c.pos-c.beats[i] < c.clickFrames {
+			t := float64(c.pos-c.beats[i]) / float64(sounds.Rates)
+			v = float32(math.Sin(2*math.Pi*clickFreq*t) * math.Exp(-t/clickDecay))
+		}
+		for ch := 0; ch < channelNum; ch++ {
+			binary.LittleEndian.PutUint32(p[n+ch*bytesPerSample:], math.Float32bits(v))
+		}
+		n += bytesPerFrame
+		c.pos++
+	}
+	return n, nil
+}
+
+func (c *ClickTrack) Seek(offset int64, whence int) (int64, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	var next int64
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = c.pos*bytesPerFrame + offset
+	case io.SeekEnd:
+		next = c.frames*bytesPerFrame + offset
+	default:
+		return 0, errors.New("sound: invalid whence")
+	}
+	if next < 0 {
+		return 0, errors.New("sound: negative position")
+	}
+	c.pos = next / bytesPerFrame
+	return c.pos * bytesPerFrame, nil
+}
@@ -0,0 +1,24 @@
+package sound
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// NewStemPlayer returns a player that decodes the stem on demand instead of
+// keeping the whole PCM in memory. Player.SetPosition stays sample exact
+// because every decoder behind Decode seeks to the exact frame.
+//
+// The stem goes through a TimeStretch so the song rate can be changed without
+// changing its pitch.
+func NewStemPlayer(ctx *audio.Context, name string, src []byte) (*audio.Player, *TimeStretch, error) {
+	s, err := Decode(name, src)
+	if err != nil {
+		return nil, nil, err
+	}
+	ts := NewTimeStretch(s, s.Length())
+	p, err := ctx.NewPlayerF32(ts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, ts, nil
+}
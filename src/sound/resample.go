@@ -0,0 +1,147 @@
+package sound
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+const resampleChunkFrames = 4096
+
+// resampler converts a float32 stereo stream from one sample rate to another
+// with linear interpolation. It reads the source in chunks so a long stem is
+// never held in memory, and Seek lands on the exact output frame.
+type resampler struct {
+	src       io.ReadSeeker
+	srcFrames int64
+	from      int
+	to        int
+	pos       int64 // output position (frame)
+
+	raw      []byte
+	buf      []float32 // cached source frames, interleaved
+	bufStart int64     // source frame of buf[0]
+	srcPos   int64     // source frame the next src.Read returns
+}
+
+func newResampler(src io.ReadSeeker, length int64, from, to int) *resampler {
+	return &resampler{
+		src:       src,
+		srcFrames: length / bytesPerFrame,
+		from:      from,
+		to:        to,
+		raw:       make([]byte, resampleChunkFrames*bytesPerFrame),
+		buf:       make([]float32, 0, (resampleChunkFrames+1)*channelNum),
+	}
+}
+
+// Length returns the size of the resampled stream in bytes.
+func (r *resampler) Length() int64 {
+	return r.frames() * bytesPerFrame
+}
+
+func (r *resampler) frames() int64 {
+	return r.srcFrames * int64(r.to) / int64(r.from)
+}
+
+func (r *resampler) Read(p []byte) (int, error) {
+	if r.pos >= r.frames() {
+		return 0, io.EOF
+	}
+
+	n := 0
+	// frames may shrink inside ensure when the source turns out shorter.
+	for n+bytesPerFrame <= len(p) && r.pos < r.frames() {
+		num := r.pos * int64(r.from)
+		idx := num / int64(r.to)
+		frac := float32(num%int64(r.to)) / float32(r.to)
+
+		if err := r.ensure(idx); err != nil {
+			if n > 0 && err == io.EOF {
+				return n, nil
+			}
+			return n, err
+		}
+
+		i := int(idx-r.bufStart) * channelNum
+		j := i
+		if i+channelNum < len(r.buf) {
+			j = i + channelNum
+		}
+		for ch := 0; ch < channelNum; ch++ {
+			a, b := r.buf[i+ch], r.buf[j+ch]
+			binary.LittleEndian.PutUint32(p[n+ch*bytesPerSample:], math.Float32bits(a+(b-a)*frac))
+		}
+		n += bytesPerFrame
+		r.pos++
+	}
+	return n, nil
+}
+
+// ensure makes sure source frames idx and idx+1 (when it exists) are cached.
+func (r *resampler) ensure(idx int64) error {
+	bufEnd := r.bufStart + int64(len(r.buf)/channelNum)
+	next := idx + 1
+	if next >= r.srcFrames {
+		next = idx
+	}
+	if idx >= r.bufStart && next < bufEnd {
+		return nil
+	}
+
+	if idx >= r.bufStart && idx < bufEnd && r.srcPos == bufEnd {
+		// Keep the tail and continue reading where the source stands.
+		keep := r.buf[int(idx-r.bufStart)*channelNum:]
+		r.buf = append(r.buf[:0], keep...)
+		r.bufStart = idx
+	} else {
+		if r.srcPos != idx {
+			if _, err := r.src.Seek(idx*bytesPerFrame, io.SeekStart); err != nil {
+				return err
+			}
+			r.srcPos = idx
+		}
+		r.buf = r.buf[:0]
+		r.bufStart = idx
+	}
+
+	n, err := io.ReadFull(r.src, r.raw)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	frames := n / bytesPerFrame
+	for k := 0; k < frames*channelNum; k++ {
+		r.buf = append(r.buf, math.Float32frombits(binary.LittleEndian.Uint32(r.raw[k*bytesPerSample:])))
+	}
+	r.srcPos += int64(frames)
+	if n < len(r.raw) {
+		// The source ended before its declared length: only the frames
+		// actually read exist.
+		r.srcFrames = min(r.srcFrames, r.srcPos)
+	}
+
+	if idx-r.bufStart >= int64(len(r.buf)/channelNum) {
+		return io.EOF
+	}
+	return nil
+}
+
+func (r *resampler) Seek(offset int64, whence int) (int64, error) {
+	var next int64
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = r.pos*bytesPerFrame + offset
+	case io.SeekEnd:
+		next = r.Length() + offset
+	default:
+		return 0, errors.New("sound: invalid whence")
+	}
+	if next < 0 {
+		return 0, errors.New("sound: negative position")
+	}
+	r.pos = next / bytesPerFrame
+	return r.pos * bytesPerFrame, nil
+}
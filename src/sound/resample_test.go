@@ -0,0 +1,98 @@
+package sound
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+)
+
+func ramp(i int) float32 {
+	return float32(i) / 1e5
+}
+
+func TestResamplerLength(t *testing.T) {
+	const frames = 10000
+	src := pcm(frames, ramp)
+	for _, tt := range []struct{ from, to int }{{22050, 44100}, {48000, 44100}, {44100, 44100}, {96000, 44100}} {
+		r := newResampler(bytes.NewReader(src), int64(len(src)), tt.from, tt.to)
+		want := int64(frames) * int64(tt.to) / int64(tt.from) * bytesPerFrame
+		if r.Length() != want {
+			t.Errorf("%d->%d: Length = %d, want %d", tt.from, tt.to, r.Length(), want)
+		}
+		out, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%d->%d: %v", tt.from, tt.to, err)
+		}
+		if int64(len(out)) != want {
+			t.Errorf("%d->%d: read %d bytes, want %d", tt.from, tt.to, len(out), want)
+		}
+	}
+}
+
+// Ramp linear tetap linear setelah interpolasi: frame keluaran k bernilai
+// sama dengan sumber pada posisi k*from/to.
+func TestResamplerInterpolates(t *testing.T) {
+	const frames = 3 * resampleChunkFrames
+	const from, to = 48000, 44100
+	src := pcm(frames, ramp)
+	r := newResampler(bytes.NewReader(src), int64(len(src)), from, to)
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range samples(t, out) {
+		want := float64(k) * from / to / 1e5
+		if math.Abs(float64(v)-want) > 1e-5 {
+			t.Fatalf("frame %d = %v, want %v", k, v, want)
+		}
+	}
+}
+
+func TestResamplerSeek(t *testing.T) {
+	const frames = 3 * resampleChunkFrames
+	src := pcm(frames, ramp)
+	full, err := io.ReadAll(newResampler(bytes.NewReader(src), int64(len(src)), 22050, 44100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := newResampler(bytes.NewReader(src), int64(len(src)), 22050, 44100)
+	for _, frame := range []int64{5000, 17, 2 * resampleChunkFrames * 2, 0} {
+		if _, err := r.Seek(frame*bytesPerFrame, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, 100*bytesPerFrame)
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			t.Fatalf("seek to %d: %v", frame, err)
+		}
+		if !bytes.Equal(buf[:n], full[frame*bytesPerFrame:][:n]) {
+			t.Errorf("seek to %d reads different frames than playing through", frame)
+		}
+	}
+}
+
+// Sumber yang lebih pendek dari panjang yang dilaporkan berhenti di frame
+// yang benar-benar ada, dan Length ikut menyusut.
+func TestResamplerShortSource(t *testing.T) {
+	const frames, declared = 5000, 8000
+	src := pcm(frames, ramp)
+	r := newResampler(bytes.NewReader(src), declared*bytesPerFrame, 22050, 44100)
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := samples(t, out)
+	if len(got) != 2*frames {
+		t.Errorf("read %d frames from %d source frames at 2x", len(got), frames)
+	}
+	if r.Length() != int64(len(out)) {
+		t.Errorf("Length = %d after reading %d bytes", r.Length(), len(out))
+	}
+	for k, v := range got {
+		if want := min(float64(k)/2, frames-1) / 1e5; math.Abs(float64(v)-want) > 1e-6 {
+			t.Fatalf("frame %d = %v, want about %v", k, v, want)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package sound
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/rizalmf/old-boys/src/constants"
+)
+
+const (
+	MinRate = constants.MinSongRate
+	MaxRate = constants.MaxSongRate
+
+	stretchWindow    = 1024              // frames per analysis segment (~23ms)
+	stretchHop       = stretchWindow / 2 // synthesis hop, 50% overlap
+	stretchTolerance = 256               // search range around the nominal position
+	stretchCorrStep  = 4                 // correlate every Nth frame to keep it cheap
+)
+
+// TimeStretch changes the playback rate of a stream without changing its
+// pitch, using WSOLA (waveform similarity overlap-add). It sits between a
+// decoded stem and the audio player. Output frame f maps to source frame
+// f*rate, so seeking stays exact in song time. At rate 1 the source is passed
+// through untouched.
+type TimeStretch struct {
+	m sync.Mutex
+
+	src       io.ReadSeeker
+	srcFrames int64
+	rate      float64
+	window    []float32
+
+	pos     int64 // output frame
+	block   int64 // index of the next synthesis block
+	skip    int   // frames to drop from the next block after Seek
+	out     []float32
+	tail    []float32 // windowed second half of the previous segment
+	ref     []float32 // mono scratch for the similarity search
+	cand    []float32
+	prevQ   int64
+	hasPrev bool
+
+	raw      []byte
+	buf      []float32 // source cache, interleaved
+	bufStart int64
+	srcPos   int64
+}
+
+func NewTimeStretch(src io.ReadSeeker, length int64) *TimeStretch {
+	w := make([]float32, stretchWindow)
+	for i := range w {
+		// Periodic Hann; windows at 50% overlap sum to 1.
+		w[i] = float32(0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/stretchWindow))
+	}
+	return &TimeStretch{
+		src:       src,
+		srcFrames: length / bytesPerFrame,
+		rate:      1,
+		window:    w,
+		tail:      make([]float32, stretchHop*channelNum),
+		raw:       make([]byte, resampleChunkFrames*bytesPerFrame),
+	}
+}
+
+// SetRate sets the playback rate, clamped to [MinRate, MaxRate]. Call it while
+// the player is paused and set the player position afterwards, since output
+// positions are measured in stretched time.
+func (t *TimeStretch) SetRate(rate float64) error {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.rate = math.Min(math.Max(rate, MinRate), MaxRate)
+	return t.seekFrame(t.pos)
+}
+
+// Length returns the size of the stretched stream in bytes.
+func (t *TimeStretch) Length() int64 {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	return t.frames() * bytesPerFrame
+}
+
+func (t *TimeStretch) frames() int64 {
+	return int64(float64(t.srcFrames) / t.rate)
+}
+
+func (t *TimeStretch) Read(p []byte) (int, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if t.rate == 1 {
+		n, err := t.src.Read(p)
+		t.pos += int64(n / bytesPerFrame)
+		t.srcPos = t.pos
+		return n, err
+	}
+
+	total := t.frames()
+	n := 0
+	for n+bytesPerFrame <= len(p) && t.pos < total {
+		if len(t.out) == 0 {
+			if err := t.nextBlock(); err != nil {
+				return n, err
+			}
+			if t.skip > 0 {
+				t.out = t.out[t.skip*channelNum:]
+				t.skip = 0
+			}
+			continue
+		}
+		for ch := 0; ch < channelNum; ch++ {
+			binary.LittleEndian.PutUint32(p[n+ch*bytesPerSample:], math.Float32bits(t.out[ch]))
+		}
+		t.out = t.out[channelNum:]
+		n += bytesPerFrame
+		t.pos++
+	}
+	if n == 0 && t.pos >= total {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// nextBlock picks the source segment around the nominal position that best
+// continues the previous one and overlap-adds it into the next stretchHop
+// output frames.
+func (t *TimeStretch) nextBlock() error {
+	if !t.hasPrev {
+		if err := t.prime(); err != nil {
+			return err
+		}
+	}
+	nominal := int64(math.Round(float64(t.block*stretchHop) * t.rate))
+	q := nominal
+
+	if t.hasPrev {
+		natural := t.prevQ + stretchHop
+		lo := max(nominal-stretchTolerance, 0)
+		hi := nominal + stretchTolerance
+		if err := t.ensure(min(lo, natural), max(hi+stretchWindow, natural+stretchHop)); err != nil {
+			return err
+		}
+
+		t.ref = t.monoRange(t.ref[:0], natural, natural+stretchHop)
+		t.cand = t.monoRange(t.cand[:0], lo, hi+stretchHop)
+		best := float32(math.Inf(-1))
+		for c := 0; c <= int(hi-lo); c++ {
+			var sim float32
+			for i := 0; i < stretchHop; i += stretchCorrStep {
+				sim += t.cand[c+i] * t.ref[i]
+			}
+			if sim > best {
+				best, q = sim, lo+int64(c)
+			}
+		}
+	} else {
+		if err := t.ensure(q, q+stretchWindow); err != nil {
+			return err
+		}
+	}
+
+	t.out = t.out[:0]
+	for i := 0; i < stretchHop; i++ {
+		for ch := 0; ch < channelNum; ch++ {
+			v := t.sample(q+int64(i), ch) * t.window[i]
+			if t.hasPrev {
+				v += t.tail[i*channelNum+ch]
+			}
+			t.out = append(t.out, v)
+		}
+	}
+	for i := stretchHop; i < stretchWindow; i++ {
+		for ch := 0; ch < channelNum; ch++ {
+			t.tail[(i-stretchHop)*channelNum+ch] = t.sample(q+int64(i), ch) * t.window[i]
+		}
+	}
+
+	t.prevQ = q
+	t.hasPrev = true
+	t.block++
+	return nil
+}
+
+// prime rebuilds the tail of the block before t.block from its nominal source
+// segment, so the first block after a seek overlap-adds onto it instead of
+// fading in from silence. The very first block has nothing before it.
+func (t *TimeStretch) prime() error {
+	if t.block == 0 {
+		return nil
+	}
+	prev := int64(math.Round(float64((t.block-1)*stretchHop) * t.rate))
+	if err := t.ensure(prev+stretchHop, prev+stretchWindow); err != nil {
+		return err
+	}
+	for i := stretchHop; i < stretchWindow; i++ {
+		for ch := 0; ch < channelNum; ch++ {
+			t.tail[(i-stretchHop)*channelNum+ch] = t.sample(prev+int64(i), ch) * t.window[i]
+		}
+	}
+	t.prevQ = prev
+	t.hasPrev = true
+	return nil
+}
+
+func (t *TimeStretch) sample(frame int64, ch int) float32 {
+	i := (frame - t.bufStart) * channelNum
+	if i < 0 || i >= int64(len(t.buf)) {
+		return 0
+	}
+	return t.buf[i+int64(ch)]
+}
+
+// monoRange appends the mono mix of frames [from, to) to dst.
+func (t *TimeStretch) monoRange(dst []float32, from, to int64) []float32 {
+	for f := from; f < to; f++ {
+		dst = append(dst, t.sample(f, 0)+t.sample(f, 1))
+	}
+	return dst
+}
+
+// ensure caches source frames [from, to). Frames past the end read as silence.
+func (t *TimeStretch) ensure(from, to int64) error {
+	bufEnd := t.bufStart + int64(len(t.buf)/channelNum)
+	if from < t.bufStart || from > bufEnd || t.srcPos != bufEnd {
+		if _, err := t.src.Seek(from*bytesPerFrame, io.SeekStart); err != nil {
+			return err
+		}
+		t.srcPos = from
+		t.buf = t.buf[:0]
+	} else {
+		t.buf = append(t.buf[:0], t.buf[(from-t.bufStart)*channelNum:]...)
+	}
+	t.bufStart = from
+
+	for t.bufStart+int64(len(t.buf)/channelNum) < to && t.srcPos < t.srcFrames {
+		n, err := io.ReadFull(t.src, t.raw)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		frames := n / bytesPerFrame
+		if frames == 0 {
+			break
+		}
+		for k := 0; k < frames*channelNum; k++ {
+			t.buf = append(t.buf, math.Float32frombits(binary.LittleEndian.Uint32(t.raw[k*bytesPerSample:])))
+		}
+		t.srcPos += int64(frames)
+	}
+	return nil
+}
+
+func (t *TimeStretch) Seek(offset int64, whence int) (int64, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	var next int64
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = t.pos*bytesPerFrame + offset
+	case io.SeekEnd:
+		next = t.frames()*bytesPerFrame + offset
+	default:
+		return 0, errors.New("sound: invalid whence")
+	}
+	if next < 0 {
+		return 0, errors.New("sound: negative position")
+	}
+	if err := t.seekFrame(next / bytesPerFrame); err != nil {
+		return 0, err
+	}
+	return t.pos * bytesPerFrame, nil
+}
+
+func (t *TimeStretch) seekFrame(frame int64) error {
+	t.pos = frame
+	t.out = t.out[:0]
+	t.hasPrev = false
+	t.block = frame / stretchHop
+	t.skip = int(frame % stretchHop)
+	t.buf = t.buf[:0]
+	t.bufStart = 0
+
+	if t.rate == 1 {
+		if _, err := t.src.Seek(frame*bytesPerFrame, io.SeekStart); err != nil {
+			return err
+		}
+		t.srcPos = frame
+	}
+	return nil
+}
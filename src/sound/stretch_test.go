@@ -0,0 +1,121 @@
+package sound
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+)
+
+// pcm membuat stream float32 stereo dengan sampel yang sama di kedua kanal.
+func pcm(frames int, f func(i int) float32) []byte {
+	b := make([]byte, 0, frames*bytesPerFrame)
+	for i := range frames {
+		for range channelNum {
+			b = binary.LittleEndian.AppendUint32(b, math.Float32bits(f(i)))
+		}
+	}
+	return b
+}
+
+// samples mengubah stream float32 stereo menjadi sampel kanal kiri.
+func samples(t *testing.T, b []byte) []float32 {
+	t.Helper()
+	if len(b)%bytesPerFrame != 0 {
+		t.Fatalf("stream of %d bytes is not whole frames", len(b))
+	}
+	s := make([]float32, len(b)/bytesPerFrame)
+	for i := range s {
+		s[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[i*bytesPerFrame:]))
+	}
+	return s
+}
+
+func sine(i int) float32 {
+	return float32(0.5 * math.Sin(2*math.Pi*440*float64(i)/44100))
+}
+
+func TestTimeStretchLength(t *testing.T) {
+	const frames = 44100
+	src := pcm(frames, sine)
+	for _, rate := range []float64{MinRate, 0.8, 1, 1.2, MaxRate} {
+		ts := NewTimeStretch(bytes.NewReader(src), int64(len(src)))
+		if err := ts.SetRate(rate); err != nil {
+			t.Fatal(err)
+		}
+		want := int64(frames/rate) * bytesPerFrame
+		if got := ts.Length(); got != want {
+			t.Errorf("rate %v: Length = %d, want %d", rate, got, want)
+		}
+		out, err := io.ReadAll(ts)
+		if err != nil {
+			t.Fatalf("rate %v: %v", rate, err)
+		}
+		if int64(len(out)) != want {
+			t.Errorf("rate %v: read %d bytes, want %d", rate, len(out), want)
+		}
+	}
+}
+
+func TestTimeStretchClampsRate(t *testing.T) {
+	src := pcm(1000, sine)
+	ts := NewTimeStretch(bytes.NewReader(src), int64(len(src)))
+	for _, tt := range []struct{ rate, want float64 }{{0.1, MinRate}, {3, MaxRate}} {
+		if err := ts.SetRate(tt.rate); err != nil {
+			t.Fatal(err)
+		}
+		if got := ts.Length() / bytesPerFrame; got != int64(1000/tt.want) {
+			t.Errorf("rate %v: %d frames, want %d", tt.rate, got, int64(1000/tt.want))
+		}
+	}
+}
+
+// Setelah seek, blok pertama tidak boleh fade-in dari hening: amplitudo
+// sinus harus langsung penuh seperti saat diputar terus.
+func TestTimeStretchSeekContinuity(t *testing.T) {
+	const frames = 4 * 44100
+	src := pcm(frames, sine)
+	for _, rate := range []float64{0.7, 1.3} {
+		ts := NewTimeStretch(bytes.NewReader(src), int64(len(src)))
+		if err := ts.SetRate(rate); err != nil {
+			t.Fatal(err)
+		}
+		for _, frame := range []int64{44100, 44100 + stretchHop/3} {
+			if _, err := ts.Seek(frame*bytesPerFrame, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+			buf := make([]byte, stretchWindow*bytesPerFrame)
+			if _, err := io.ReadFull(ts, buf); err != nil {
+				t.Fatal(err)
+			}
+			out := samples(t, buf)
+			// Satu periode 440 Hz sekitar 100 frame; setiap potongan 128 frame
+			// harus berisi puncak mendekati 0.5.
+			for i := 0; i+128 <= len(out); i += 128 {
+				peak := float32(0)
+				for _, v := range out[i : i+128] {
+					peak = max(peak, float32(math.Abs(float64(v))))
+				}
+				if peak < 0.4 {
+					t.Errorf("rate %v, seek to %d: peak %.2f at frames %d-%d, want about 0.5", rate, frame, peak, i, i+128)
+				}
+			}
+		}
+	}
+}
+
+func TestTimeStretchPassThrough(t *testing.T) {
+	src := pcm(5000, sine)
+	ts := NewTimeStretch(bytes.NewReader(src), int64(len(src)))
+	if _, err := ts.Seek(1234*bytesPerFrame, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	out, err := io.ReadAll(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, src[1234*bytesPerFrame:]) {
+		t.Error("rate 1 does not pass the source through after a seek")
+	}
+}